@@ -0,0 +1,60 @@
+package set
+
+import "sync"
+
+// Arena pools the maps backing sets so that workloads computing many
+// short-lived unions can reuse them instead of allocating a new map each
+// time. It is an opt-in optimization; plain [Set.Union] is simpler and
+// should be preferred unless allocation profiles show set maps dominating.
+//
+// Lifetime rules:
+//   - A set returned by [Arena.Union] is an ordinary Set until it is passed
+//     to [Arena.Release].
+//   - After Release, neither that set nor any copy of it may be used, since
+//     copies share the map and the arena will hand it to a later Union.
+//     Clone a result first if any part of it must outlive the release.
+//   - Release each set at most once, and only sets obtained from the same
+//     arena.
+//
+// Released maps are cleared, so they hold no references to old elements,
+// but Go maps do not shrink: a pooled map keeps the capacity of the largest
+// set it has held until the pool drops it.
+//
+// The zero value is ready to use. An Arena is safe for concurrent use and
+// must not be copied after first use.
+type Arena[T comparable] struct {
+	pool sync.Pool // holds map[T]struct{}
+}
+
+// NewArena creates an empty Arena.
+func NewArena[T comparable]() *Arena[T] {
+	return &Arena[T]{}
+}
+
+// Union returns a set containing every element of x and y, like
+// [Set.Union], whose map is taken from the arena's pool when one is
+// available. Pass the result to [Arena.Release] once it is no longer needed.
+func (a *Arena[T]) Union(x, y Set[T]) Set[T] {
+	m, ok := a.pool.Get().(map[T]struct{})
+	if !ok {
+		m = make(map[T]struct{}, len(x.m)+len(y.m))
+	}
+	for k := range x.m {
+		m[k] = struct{}{}
+	}
+	for k := range y.m {
+		m[k] = struct{}{}
+	}
+	return Set[T]{m: m}
+}
+
+// Release clears s and returns its map to the arena's pool. s and every
+// copy of it must not be used afterwards. Releasing a set with no backing
+// map is a no-op.
+func (a *Arena[T]) Release(s Set[T]) {
+	if s.m == nil {
+		return
+	}
+	clear(s.m)
+	a.pool.Put(s.m)
+}
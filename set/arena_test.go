@@ -0,0 +1,38 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaUnion(t *testing.T) {
+	a := NewArena[int]()
+	u := a.Union(Of(1, 2), Of(2, 3))
+	assert.Equal(t, []int{1, 2, 3}, sorted(u.Values()))
+
+	a.Release(u)
+	assert.True(t, u.IsEmpty(), "expected Release to clear the set's map")
+
+	v := a.Union(Of(7), Set[int]{})
+	assert.Equal(t, []int{7}, v.Values(), "expected no elements leaked from a released set")
+	a.Release(v)
+	a.Release(Set[int]{})
+
+	var zero Arena[string]
+	w := zero.Union(Set[string]{}, Set[string]{})
+	assert.True(t, w.IsEmpty())
+	w.Add("x")
+	assert.True(t, w.Contains("x"), "expected an empty arena union to be usable")
+}
+
+// BenchmarkArenaSmallUnion is the pooled counterpart of BenchmarkSmallUnion.
+func BenchmarkArenaSmallUnion(b *testing.B) {
+	a := Of(1, 2, 3)
+	c := Of(3, 4, 5)
+	arena := NewArena[int]()
+	b.ResetTimer()
+	for range b.N {
+		arena.Release(arena.Union(a, c))
+	}
+}
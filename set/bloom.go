@@ -0,0 +1,125 @@
+package set
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// BloomBacked is a [Set] paired with a Bloom filter that answers most
+// negative Contains queries without touching the map. It pays off for very
+// large sets where the majority of lookups are for absent elements.
+//
+// Remove keeps the filter correct but cannot clear the removed element's
+// bits, so heavy removal gradually raises the false-positive rate and sends
+// more lookups to the map. Call [BloomBacked.Rebuild] to restore it.
+//
+// The zero value is not usable; create instances with [NewBloomBacked].
+type BloomBacked[T comparable] struct {
+	set  Set[T]
+	bits []uint64
+	mask uint64 // number of bits minus one; always a power of two minus one
+	k    uint64 // number of hash probes per element
+	seed maphash.Seed
+}
+
+// NewBloomBacked creates an empty BloomBacked set whose filter is sized for
+// expectedN elements at a false-positive rate of fpRate. It panics if fpRate
+// is not strictly between 0 and 1.
+func NewBloomBacked[T comparable](expectedN int, fpRate float64) *BloomBacked[T] {
+	if !(fpRate > 0 && fpRate < 1) {
+		panic("set: bloom filter false-positive rate must be in (0, 1)")
+	}
+	n := float64(max(expectedN, 1))
+	bits := math.Ceil(-n * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := max(uint64(math.Round(bits/n*math.Ln2)), 1)
+	// Round the bit count up to a power of two so probes can mask instead
+	// of taking a modulus.
+	nbits := uint64(64)
+	for float64(nbits) < bits {
+		nbits <<= 1
+	}
+	return &BloomBacked[T]{
+		set:  New[T](max(expectedN, 0)),
+		bits: make([]uint64, nbits/64),
+		mask: nbits - 1,
+		k:    k,
+		seed: maphash.MakeSeed(),
+	}
+}
+
+// Add inserts elem into the set. It returns true if the element was added,
+// or false if it was already present.
+func (b *BloomBacked[T]) Add(elem T) bool {
+	if !b.set.Add(elem) {
+		return false
+	}
+	b.mark(elem)
+	return true
+}
+
+// AddRange inserts one or more elements into the set.
+func (b *BloomBacked[T]) AddRange(elems ...T) {
+	for _, e := range elems {
+		b.Add(e)
+	}
+}
+
+// Remove deletes one or more elements from the set. The filter keeps their
+// bits; see [BloomBacked] for the effect on lookups.
+func (b *BloomBacked[T]) Remove(elems ...T) {
+	b.set.Remove(elems...)
+}
+
+// Contains reports whether the set contains elem. Elements rejected by the
+// filter are answered without a map lookup.
+func (b *BloomBacked[T]) Contains(elem T) bool {
+	if !b.mayContain(elem) {
+		return false
+	}
+	return b.set.Contains(elem)
+}
+
+// Len returns the number of elements in the set.
+func (b *BloomBacked[T]) Len() int {
+	return b.set.Len()
+}
+
+// All returns an iterator over all elements of the set.
+func (b *BloomBacked[T]) All() func(yield func(T) bool) {
+	return b.set.All()
+}
+
+// Rebuild clears the filter and re-marks only the current elements,
+// discarding bits left behind by removed elements.
+func (b *BloomBacked[T]) Rebuild() {
+	clear(b.bits)
+	for e := range b.set.m {
+		b.mark(e)
+	}
+}
+
+// hashes returns the two base hashes from which the k probe positions are
+// derived by double hashing: probe i is (h1 + i*h2) & b.mask.
+func (b *BloomBacked[T]) hashes(elem T) (h1, h2 uint64) {
+	h := maphash.Comparable(b.seed, elem)
+	return h, h>>32 | 1
+}
+
+func (b *BloomBacked[T]) mark(elem T) {
+	h1, h2 := b.hashes(elem)
+	for i := range b.k {
+		bit := (h1 + i*h2) & b.mask
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (b *BloomBacked[T]) mayContain(elem T) bool {
+	h1, h2 := b.hashes(elem)
+	for i := range b.k {
+		bit := (h1 + i*h2) & b.mask
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
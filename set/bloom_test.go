@@ -0,0 +1,100 @@
+package set
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomBackedAddContains(t *testing.T) {
+	b := NewBloomBacked[int](1000, 0.01)
+	for i := range 1000 {
+		assert.True(t, b.Add(i), "expected Add(%d) to return true", i)
+	}
+	assert.False(t, b.Add(5), "expected Add to return false for duplicate element")
+	require.Equal(t, 1000, b.Len())
+
+	for i := range 1000 {
+		require.True(t, b.Contains(i), "expected set to contain %d", i)
+	}
+	for i := 1000; i < 2000; i++ {
+		require.False(t, b.Contains(i), "expected set to not contain %d", i)
+	}
+}
+
+func TestBloomBackedFalsePositiveRate(t *testing.T) {
+	b := NewBloomBacked[int](10_000, 0.01)
+	for i := range 10_000 {
+		b.Add(i)
+	}
+	hits := 0
+	for i := 10_000; i < 110_000; i++ {
+		if b.mayContain(i) {
+			hits++
+		}
+	}
+	assert.Less(t, float64(hits)/100_000, 0.03, "filter false-positive rate far above target")
+}
+
+func TestBloomBackedRemoveAndRebuild(t *testing.T) {
+	b := NewBloomBacked[string](10, 0.01)
+	b.AddRange("a", "b", "c")
+	b.Remove("b")
+	assert.False(t, b.Contains("b"), "expected removed element to be absent")
+	assert.True(t, b.mayContain("b"), "expected stale filter bits before Rebuild")
+	assert.Equal(t, 2, b.Len())
+
+	b.Rebuild()
+	assert.True(t, b.Contains("a"), "expected Rebuild to keep current elements")
+	assert.True(t, b.Contains("c"), "expected Rebuild to keep current elements")
+	assert.False(t, b.Contains("b"))
+
+	var got []string
+	for e := range b.All() {
+		got = append(got, e)
+	}
+	assert.ElementsMatch(t, []string{"a", "c"}, got)
+}
+
+func TestBloomBackedInvalidRate(t *testing.T) {
+	assert.Panics(t, func() { NewBloomBacked[int](10, 0) })
+	assert.Panics(t, func() { NewBloomBacked[int](10, 1) })
+	assert.NotPanics(t, func() { NewBloomBacked[int](0, 0.5).Add(1) })
+	assert.NotPanics(t, func() { NewBloomBacked[int](-5, 0.5).Add(1) }, "expected a negative size hint to be treated as zero")
+}
+
+func BenchmarkContainsNegativeMap(b *testing.B) {
+	s := New[string](1_000_000)
+	for i := range 1_000_000 {
+		s.Add(keyFor(i))
+	}
+	misses := make([]string, 1<<20)
+	for i := range misses {
+		misses[i] = keyFor(-i - 1)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		s.Contains(misses[i%len(misses)])
+	}
+}
+
+func BenchmarkContainsNegativeBloom(b *testing.B) {
+	s := NewBloomBacked[string](1_000_000, 0.01)
+	for i := range 1_000_000 {
+		s.Add(keyFor(i))
+	}
+	misses := make([]string, 1<<20)
+	for i := range misses {
+		misses[i] = keyFor(-i - 1)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		s.Contains(misses[i%len(misses)])
+	}
+}
+
+func keyFor(i int) string {
+	return "element-" + strconv.Itoa(i)
+}
@@ -0,0 +1,50 @@
+package set
+
+// Builder assembles a [Set] through chained calls, for example
+//
+//	s := set.NewBuilder[int]().Add(1, 2).AddSet(other).Remove(3).Build()
+//
+// Build hands the accumulated set to the caller and resets the builder to
+// empty, so later calls start a new set and never modify one already built.
+// The zero value is ready to use.
+type Builder[T comparable] struct {
+	s Set[T]
+}
+
+// NewBuilder creates an empty Builder. An optional capacity pre-sizes the
+// set under construction, as with [New].
+func NewBuilder[T comparable](capacity ...int) *Builder[T] {
+	return &Builder[T]{s: New[T](capacity...)}
+}
+
+// Add inserts elems into the set under construction and returns b.
+func (b *Builder[T]) Add(elems ...T) *Builder[T] {
+	b.s.AddRange(elems...)
+	return b
+}
+
+// AddSet inserts every element of other into the set under construction
+// and returns b.
+func (b *Builder[T]) AddSet(other Set[T]) *Builder[T] {
+	b.s.AddSet(other)
+	return b
+}
+
+// Remove deletes elems from the set under construction and returns b.
+func (b *Builder[T]) Remove(elems ...T) *Builder[T] {
+	b.s.Remove(elems...)
+	return b
+}
+
+// Len returns the number of elements added so far.
+func (b *Builder[T]) Len() int {
+	return b.s.Len()
+}
+
+// Build returns the finished set and resets b to empty. The returned set is
+// never aliased by the builder.
+func (b *Builder[T]) Build() Set[T] {
+	s := b.s
+	b.s = Set[T]{}
+	return s
+}
@@ -0,0 +1,34 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	other := Of(10, 20)
+	s := NewBuilder[int]().Add(1, 2, 3).AddSet(other).Remove(2, 99).Build()
+	assert.Equal(t, []int{1, 3, 10, 20}, sorted(s.Values()))
+}
+
+func TestBuilderResetsAfterBuild(t *testing.T) {
+	b := NewBuilder[string](4).Add("a", "b")
+	require.Equal(t, 2, b.Len())
+	first := b.Build()
+	assert.Equal(t, 0, b.Len(), "expected Build to reset the builder")
+
+	second := b.Add("c").Build()
+	assert.ElementsMatch(t, []string{"a", "b"}, first.Values(), "expected built set to be unaffected by later builder use")
+	assert.Equal(t, []string{"c"}, second.Values())
+}
+
+func TestBuilderZeroValue(t *testing.T) {
+	var b Builder[int]
+	s := b.Build()
+	assert.True(t, s.IsEmpty())
+	s.Add(1)
+	assert.True(t, s.Contains(1), "expected built empty set to be usable")
+	assert.Equal(t, 1, b.Add(1).Build().Len())
+}
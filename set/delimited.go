@@ -0,0 +1,28 @@
+package set
+
+import (
+	"slices"
+	"strings"
+)
+
+// EncodeDelimited joins the elements of s with sep in ascending order, for
+// use in PATH-style or comma-separated configuration values. Elements that
+// contain sep will not survive a round trip through [DecodeDelimited].
+func EncodeDelimited(s Set[string], sep string) string {
+	v := s.Values()
+	slices.Sort(v)
+	return strings.Join(v, sep)
+}
+
+// DecodeDelimited splits data on sep and returns the set of fields. Empty
+// fields, such as those produced by leading, trailing, or repeated
+// separators, are skipped.
+func DecodeDelimited(data, sep string) Set[string] {
+	s := New[string]()
+	for field := range strings.SplitSeq(data, sep) {
+		if field != "" {
+			s.m[field] = struct{}{}
+		}
+	}
+	return s
+}
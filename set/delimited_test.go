@@ -0,0 +1,26 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDelimited(t *testing.T) {
+	assert.Equal(t, "/bin:/usr/bin:/usr/local/bin", EncodeDelimited(Of("/usr/local/bin", "/bin", "/usr/bin"), ":"))
+	assert.Equal(t, "a, b", EncodeDelimited(Of("b", "a"), ", "))
+	assert.Equal(t, "", EncodeDelimited(Set[string]{}, ","))
+}
+
+func TestDecodeDelimited(t *testing.T) {
+	s := DecodeDelimited("b,,a,b,", ",")
+	assert.Equal(t, []string{"a", "b"}, ValuesSorted(s))
+
+	assert.True(t, DecodeDelimited("", ":").IsEmpty(), "expected empty set from empty input")
+	assert.True(t, DecodeDelimited(":::", ":").IsEmpty(), "expected empty set from only separators")
+}
+
+func TestDelimitedRoundTrip(t *testing.T) {
+	s := Of("x", "y", "z")
+	assert.True(t, s.Equal(DecodeDelimited(EncodeDelimited(s, "|"), "|")), "expected round trip to preserve elements")
+}
@@ -0,0 +1,44 @@
+package set
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EqualFold reports whether a and b are equal under Unicode case folding,
+// as defined by [strings.EqualFold]: each element of a can be paired with a
+// distinct element of b that it matches case-insensitively, with none left
+// over. Folding can collapse distinct elements, so {"Go", "GO"} equals
+// {"go", "gO"} but not {"go", "rust"}.
+func EqualFold(a, b Set[string]) bool {
+	if len(a.m) != len(b.m) {
+		return false
+	}
+	// Count elements per folded form; a pairing exists exactly when every
+	// folded form occurs equally often on both sides.
+	counts := make(map[string]int, len(a.m))
+	for e := range a.m {
+		counts[foldKey(e)]++
+	}
+	for e := range b.m {
+		k := foldKey(e)
+		if counts[k] == 0 {
+			return false
+		}
+		counts[k]--
+	}
+	return true
+}
+
+// foldKey maps s to a canonical form shared by every string it matches
+// under [strings.EqualFold], by replacing each rune with the smallest rune
+// in its case-folding orbit.
+func foldKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		lowest := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			lowest = min(lowest, f)
+		}
+		return lowest
+	}, s)
+}
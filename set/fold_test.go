@@ -0,0 +1,37 @@
+package set
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualFold(t *testing.T) {
+	for _, tc := range []struct {
+		a, b Set[string]
+		want bool
+	}{
+		{Of("Content-Type", "Accept"), Of("accept", "CONTENT-TYPE"), true},
+		{Of("Go", "GO"), Of("go", "gO"), true},
+		{Of("Go", "GO"), Of("go", "rust"), false},
+		{Of("Go", "GO"), Of("go", "go2"), false},
+		{Of("a"), Of("a", "b"), false},
+		{Of("a", "b"), Of("A", "c"), false},
+		{Of("straße"), Of("STRASSE"), false}, // EqualFold uses simple folding only
+		{Of("K"), Of("K"), true},             // Kelvin sign folds to K
+		{Set[string]{}, Set[string]{}, true},
+	} {
+		assert.Equal(t, tc.want, EqualFold(tc.a, tc.b), "EqualFold(%v, %v)", tc.a, tc.b)
+		assert.Equal(t, tc.want, EqualFold(tc.b, tc.a), "EqualFold(%v, %v)", tc.b, tc.a)
+	}
+}
+
+func TestFoldKeyMatchesStringsEqualFold(t *testing.T) {
+	words := []string{"k", "K", "K", "σ", "Σ", "ς", "ǅ", "ǆ", "Ǆ", "İ", "i", "ß", "ẞ", "hello", "HeLLo"}
+	for _, x := range words {
+		for _, y := range words {
+			assert.Equal(t, strings.EqualFold(x, y), foldKey(x) == foldKey(y), "%q vs %q", x, y)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package set
+
+// growHook is a pending [Set.OnGrow] registration.
+type growHook[T comparable] struct {
+	threshold int
+	cb        func(Set[T])
+}
+
+// OnGrow registers cb to be called once, when an Add, AddRange, AddAll, or
+// AddSet takes the set from fewer than threshold elements to at least
+// threshold. A set that already holds threshold elements does not fire on
+// its next add; it must first shrink below threshold. After firing, the hook
+// is cleared, so cb may register a new one. A later call to OnGrow replaces
+// any pending hook, and a nil cb removes it. Copies of s made after OnGrow
+// share the hook, which fires at most once across all of them.
+//
+// The hook is stored behind a pointer field in Set, so sets that never call
+// OnGrow carry one extra word and pay a nil check per add.
+func (s *Set[T]) OnGrow(threshold int, cb func(Set[T])) {
+	if cb == nil {
+		s.grow = nil
+		return
+	}
+	s.grow = &growHook[T]{threshold: threshold, cb: cb}
+}
+
+// checkGrow fires the pending hook if the set grew from before elements to
+// at least the threshold.
+func (s *Set[T]) checkGrow(before int) {
+	h := s.grow
+	if h.cb == nil {
+		s.grow = nil // fired through a copy
+		return
+	}
+	if before >= h.threshold || len(s.m) < h.threshold {
+		return
+	}
+	cb := h.cb
+	h.cb = nil
+	s.grow = nil
+	cb(*s)
+}
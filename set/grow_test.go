@@ -0,0 +1,83 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnGrow(t *testing.T) {
+	var s Set[int]
+	fired := 0
+	s.OnGrow(3, func(got Set[int]) {
+		fired++
+		assert.Equal(t, 3, got.Len(), "expected callback to see the grown set")
+	})
+	s.Add(1)
+	s.Add(2)
+	s.Add(2)
+	assert.Equal(t, 0, fired, "expected no callback below the threshold")
+	s.Add(3)
+	assert.Equal(t, 1, fired, "expected callback at the threshold")
+	s.Add(4)
+	assert.Equal(t, 1, fired, "expected callback to fire only once")
+}
+
+func TestOnGrowRequiresCrossing(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	fired := 0
+	s.OnGrow(3, func(Set[int]) { fired++ })
+	s.Add(5)
+	s.AddRange(6, 7)
+	assert.Equal(t, 0, fired, "expected no callback when registered above the threshold")
+
+	s.Remove(3, 4, 5, 6, 7)
+	s.Add(3)
+	assert.Equal(t, 1, fired, "expected callback after shrinking below and growing back")
+}
+
+func TestOnGrowBatchAdds(t *testing.T) {
+	for name, add := range map[string]func(s *Set[int]){
+		"AddRange": func(s *Set[int]) { s.AddRange(1, 2, 3, 4, 5) },
+		"AddAll":   func(s *Set[int]) { s.AddAll([]int{1, 2}, []int{3, 4, 5}) },
+		"AddSet":   func(s *Set[int]) { s.AddSet(Of(1, 2, 3, 4, 5)) },
+	} {
+		s := New[int]()
+		fired := false
+		s.OnGrow(4, func(Set[int]) { fired = true })
+		add(&s)
+		assert.True(t, fired, "%s: expected callback", name)
+	}
+}
+
+func TestOnGrowReRegister(t *testing.T) {
+	s := New[int]()
+	var thresholds []int
+	var hook func(Set[int])
+	hook = func(got Set[int]) {
+		thresholds = append(thresholds, got.Len())
+		s.OnGrow(got.Len()*2, hook)
+	}
+	s.OnGrow(1, hook)
+	for i := range 10 {
+		s.Add(i)
+	}
+	assert.Equal(t, []int{1, 2, 4, 8}, thresholds)
+}
+
+func TestOnGrowRemove(t *testing.T) {
+	s := New[int]()
+	s.OnGrow(1, func(Set[int]) { t.Fatal("removed hook should not fire") })
+	s.OnGrow(1, nil)
+	s.Add(1)
+}
+
+func TestOnGrowSharedByCopies(t *testing.T) {
+	s := New[int]()
+	fired := 0
+	s.OnGrow(2, func(Set[int]) { fired++ })
+	c := s
+	s.AddRange(1, 2)
+	c.Add(3)
+	assert.Equal(t, 1, fired, "expected hook to fire once across copies")
+}
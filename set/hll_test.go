@@ -0,0 +1,43 @@
+package set
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertWithin checks that got is within tol (a fraction) of want.
+func assertWithin(t *testing.T, want, got int, tol float64) {
+	t.Helper()
+	assert.LessOrEqual(t, math.Abs(float64(got-want))/float64(want), tol, "estimate %d too far from %d", got, want)
+}
+
+func TestHLLEstimate(t *testing.T) {
+	for _, n := range []int{10, 1_000, 200_000} {
+		h := NewHLL[int](14) // standard error about 0.8%
+		for i := range n {
+			h.Add(i)
+			h.Add(i) // duplicates must not count
+		}
+		assertWithin(t, n, h.EstimateLen(), 0.04)
+	}
+	assert.Equal(t, 0, NewHLL[string](4).EstimateLen())
+}
+
+func TestHLLMerge(t *testing.T) {
+	a, b := NewHLL[string](12), NewHLL[string](12)
+	for i := range 50_000 {
+		a.Add(keyFor(i))
+		b.Add(keyFor(i + 25_000))
+	}
+	a.Merge(b)
+	assertWithin(t, 75_000, a.EstimateLen(), 0.06)
+
+	assert.Panics(t, func() { a.Merge(NewHLL[string](10)) })
+}
+
+func TestHLLInvalidPrecision(t *testing.T) {
+	assert.Panics(t, func() { NewHLL[int](3) })
+	assert.Panics(t, func() { NewHLL[int](19) })
+}
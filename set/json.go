@@ -1,6 +1,11 @@
 package set
 
-import "encoding/json"
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+)
 
 // MarshalJSON implements the json.Marshaler interface.
 // The set is serialized as a JSON array of its elements.
@@ -25,3 +30,54 @@ func (s *Set[T]) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// EncodeJSON writes the set to w as a JSON array, encoding one element at a
+// time so that no byte slice holding the whole array is built. The output
+// is equivalent to [Set.MarshalJSON].
+func (s Set[T]) EncodeJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteByte('[')
+	first := true
+	for k := range s.m {
+		if !first {
+			bw.WriteByte(',')
+		}
+		first = false
+		data, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		bw.Write(data)
+	}
+	bw.WriteByte(']')
+	return bw.Flush()
+}
+
+// DecodeJSON reads a JSON array from r and returns the set of its elements,
+// decoding one element at a time rather than buffering the whole array. A
+// JSON null yields an empty set, as with [Set.UnmarshalJSON].
+func DecodeJSON[T comparable](r io.Reader) (Set[T], error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return Set[T]{}, err
+	}
+	if tok == nil {
+		return Set[T]{}, nil
+	}
+	if tok != json.Delim('[') {
+		return Set[T]{}, errors.New("set: expected JSON array")
+	}
+	s := New[T]()
+	for dec.More() {
+		var e T
+		if err := dec.Decode(&e); err != nil {
+			return Set[T]{}, err
+		}
+		s.m[e] = struct{}{}
+	}
+	if _, err := dec.Token(); err != nil {
+		return Set[T]{}, err
+	}
+	return s, nil
+}
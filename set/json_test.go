@@ -1,7 +1,10 @@
 package set
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -116,3 +119,59 @@ func TestJSON_DuplicatesInInput(t *testing.T) {
 	assert.Equal(t, 3, s.Len())
 	assert.True(t, s.ContainsAll(1, 2, 3))
 }
+
+func TestEncodeJSON_MatchesMarshal(t *testing.T) {
+	for _, s := range []Set[int]{{}, Of(1), Of(1, 2, 3, 4, 5)} {
+		var buf bytes.Buffer
+		require.NoError(t, s.EncodeJSON(&buf))
+		want, err := json.Marshal(s)
+		require.NoError(t, err)
+		var got, exp []int
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.NoError(t, json.Unmarshal(want, &exp))
+		assert.ElementsMatch(t, exp, got)
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestEncodeJSON_WriteError(t *testing.T) {
+	assert.Error(t, Of(1, 2).EncodeJSON(failWriter{}))
+}
+
+func TestDecodeJSON_Basic(t *testing.T) {
+	s, err := DecodeJSON[string](strings.NewReader(`["a", "b", "a"]`))
+	require.NoError(t, err)
+	assert.True(t, s.Equal(Of("a", "b")))
+}
+
+func TestDecodeJSON_NullAndEmpty(t *testing.T) {
+	s, err := DecodeJSON[int](strings.NewReader(`null`))
+	require.NoError(t, err)
+	assert.True(t, s.IsEmpty())
+
+	s, err = DecodeJSON[int](strings.NewReader(`[]`))
+	require.NoError(t, err)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestDecodeJSON_Invalid(t *testing.T) {
+	for _, in := range []string{``, `{}`, `[1,`, `["a"]`, `not json`} {
+		_, err := DecodeJSON[int](strings.NewReader(in))
+		assert.Error(t, err, "DecodeJSON(%q)", in)
+	}
+}
+
+func TestJSON_StreamRoundTrip(t *testing.T) {
+	original := New[int]()
+	for i := range 10_000 {
+		original.Add(i)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, original.EncodeJSON(&buf))
+	restored, err := DecodeJSON[int](&buf)
+	require.NoError(t, err)
+	assert.True(t, original.Equal(restored))
+}
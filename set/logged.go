@@ -0,0 +1,60 @@
+package set
+
+// Logged wraps a [Set] and reports every mutation to a callback, which helps
+// trace how a set reached an unexpected state. Read operations pass straight
+// through without logging. Use it only where tracing is wanted; plain sets
+// carry no logging overhead.
+type Logged[T comparable] struct {
+	set Set[T]
+	log func(op string, elem T)
+}
+
+// NewLogged returns a Logged wrapping base. Mutations go to base's backing
+// map, so they are visible through base as well, unless base is a zero-value
+// set. log is called with op "add" or "remove" and the element for every
+// element passed to a mutating method, whether or not it changed the set.
+func NewLogged[T comparable](base Set[T], log func(op string, elem T)) *Logged[T] {
+	return &Logged[T]{set: base, log: log}
+}
+
+// Add inserts elem into the set. It returns true if the element was added,
+// or false if it was already present.
+func (l *Logged[T]) Add(elem T) bool {
+	l.log("add", elem)
+	return l.set.Add(elem)
+}
+
+// AddRange inserts one or more elements into the set.
+func (l *Logged[T]) AddRange(elems ...T) {
+	for _, e := range elems {
+		l.Add(e)
+	}
+}
+
+// Remove deletes one or more elements from the set.
+func (l *Logged[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		l.log("remove", e)
+		l.set.Remove(e)
+	}
+}
+
+// Contains reports whether the set contains elem.
+func (l *Logged[T]) Contains(elem T) bool {
+	return l.set.Contains(elem)
+}
+
+// Len returns the number of elements in the set.
+func (l *Logged[T]) Len() int {
+	return l.set.Len()
+}
+
+// All returns an iterator over all elements of the set.
+func (l *Logged[T]) All() func(yield func(T) bool) {
+	return l.set.All()
+}
+
+// Set returns the wrapped set.
+func (l *Logged[T]) Set() Set[T] {
+	return l.set
+}
@@ -0,0 +1,41 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogged(t *testing.T) {
+	var ops []string
+	base := Of(1)
+	l := NewLogged(base, func(op string, elem int) {
+		ops = append(ops, fmt.Sprintf("%s %d", op, elem))
+	})
+
+	assert.True(t, l.Add(2))
+	assert.False(t, l.Add(1))
+	l.AddRange(3, 4)
+	l.Remove(1, 9)
+	assert.True(t, l.Contains(2))
+	assert.Equal(t, 3, l.Len())
+
+	assert.Equal(t, []string{"add 2", "add 1", "add 3", "add 4", "remove 1", "remove 9"}, ops)
+	assert.True(t, base.Equal(Of(2, 3, 4)), "expected mutations to reach the base set, got %v", base)
+	assert.True(t, l.Set().Equal(base))
+
+	count := 0
+	for range l.All() {
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestLoggedZeroValueBase(t *testing.T) {
+	n := 0
+	l := NewLogged(Set[string]{}, func(string, string) { n++ })
+	l.Add("a")
+	assert.Equal(t, 1, n)
+	assert.True(t, l.Set().Contains("a"))
+}
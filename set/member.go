@@ -0,0 +1,22 @@
+package set
+
+// Member is implemented by anything that can test membership. Accept a
+// Member rather than a concrete [Set] when only Contains is needed, so that
+// callers can pass a Set, a [*BloomBacked], or a predicate via [MemberFunc].
+type Member[T comparable] interface {
+	Contains(elem T) bool
+}
+
+// MemberFunc adapts a predicate to the [Member] interface.
+type MemberFunc[T comparable] func(T) bool
+
+// Contains reports whether f returns true for elem.
+func (f MemberFunc[T]) Contains(elem T) bool {
+	return f(elem)
+}
+
+var (
+	_ Member[int] = Set[int]{}
+	_ Member[int] = (*BloomBacked[int])(nil)
+	_ Member[int] = MemberFunc[int](nil)
+)
@@ -0,0 +1,28 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func countMembers(m Member[int], elems ...int) int {
+	n := 0
+	for _, e := range elems {
+		if m.Contains(e) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMember(t *testing.T) {
+	assert.Equal(t, 2, countMembers(Of(1, 3, 5), 1, 2, 3, 4))
+
+	even := MemberFunc[int](func(n int) bool { return n%2 == 0 })
+	assert.Equal(t, 2, countMembers(even, 1, 2, 3, 4))
+
+	b := NewBloomBacked[int](10, 0.01)
+	b.Add(4)
+	assert.Equal(t, 1, countMembers(b, 1, 2, 3, 4))
+}
@@ -0,0 +1,107 @@
+package set
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesSorted(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 5}, ValuesSorted(Of(3, 5, 1, 2)))
+	assert.Equal(t, []string{"a", "b", "c"}, ValuesSorted(Of("c", "a", "b")))
+	assert.Empty(t, ValuesSorted(Set[int]{}), "expected no values from zero-value set")
+}
+
+func TestSorted(t *testing.T) {
+	s := Of(3, 1, 2)
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(Sorted(s)))
+
+	desc := s.Sorted(func(a, b int) int { return cmp.Compare(b, a) })
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(desc))
+
+	var got []int
+	for v := range Sorted(s) {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, got, "expected iteration to stop on break")
+	assert.Empty(t, slices.Collect(Sorted(Set[int]{})))
+}
+
+func TestUnionSorted(t *testing.T) {
+	got := UnionSorted(Of(5, 1, 3), Of(3, 4), Set[int]{}, Of(1, 9))
+	assert.Equal(t, []int{1, 3, 4, 5, 9}, got)
+	assert.Equal(t, []string{"a", "b"}, UnionSorted(Of("b", "a")))
+	assert.Empty(t, UnionSorted[int]())
+}
+
+func TestIsSubsetOfSorted(t *testing.T) {
+	for _, tc := range []struct {
+		a, b Set[int]
+		want bool
+	}{
+		{Of(1, 3), Of(1, 2, 3), true},
+		{Of(1, 4), Of(1, 2, 3), false},
+		{Of(0), Of(1, 2, 3), false},
+		{Of(1, 2, 3), Of(1, 2, 3), true},
+		{Of(1, 2, 3, 4), Of(1, 2, 3), false},
+		{Set[int]{}, Set[int]{}, true},
+		{Set[int]{}, Of(1), true},
+	} {
+		assert.Equal(t, tc.want, IsSubsetOfSorted(tc.a, tc.b), "IsSubsetOfSorted(%v, %v)", tc.a, tc.b)
+		assert.Equal(t, tc.a.IsSubsetOf(tc.b), IsSubsetOfSorted(tc.a, tc.b), "expected agreement with IsSubsetOf")
+	}
+}
+
+func benchmarkSubsetSets(n int) (Set[int], Set[int]) {
+	a, b := New[int](n), New[int](2*n)
+	for i := range 2 * n {
+		b.Add(i * 7919 % (2 * n))
+		if i < n {
+			a.Add(i * 2)
+		}
+	}
+	return a, b
+}
+
+func BenchmarkIsSubsetOfHash(b *testing.B) {
+	for _, n := range []int{100, 100_000} {
+		x, y := benchmarkSubsetSets(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for range b.N {
+				x.IsSubsetOf(y)
+			}
+		})
+	}
+}
+
+func BenchmarkIsSubsetOfSorted(b *testing.B) {
+	for _, n := range []int{100, 100_000} {
+		x, y := benchmarkSubsetSets(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for range b.N {
+				IsSubsetOfSorted(x, y)
+			}
+		})
+	}
+}
+
+func TestForEachSorted(t *testing.T) {
+	s := Of(3, 1, 2)
+	var got []int
+	ForEachSorted(s, func(v int) { got = append(got, v) })
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	got = nil
+	s.ForEachSorted(func(a, b int) int { return cmp.Compare(b, a) }, func(v int) {
+		got = append(got, v)
+		s.Remove(v)
+	})
+	assert.Equal(t, []int{3, 2, 1}, got)
+	assert.True(t, s.IsEmpty(), "expected f to be allowed to modify the set")
+}
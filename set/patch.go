@@ -0,0 +1,25 @@
+package set
+
+// SetPatch describes the changes that turn one set into another. It can be
+// serialized (both fields marshal as JSON arrays) and applied elsewhere to a
+// set equal to the original base with [Set.Apply].
+type SetPatch[T comparable] struct {
+	Adds    Set[T] `json:"adds"`
+	Removes Set[T] `json:"removes"`
+}
+
+// PatchTo returns the patch that transforms s into target.
+func (s Set[T]) PatchTo(target Set[T]) SetPatch[T] {
+	return SetPatch[T]{
+		Adds:    target.Difference(s),
+		Removes: s.Difference(target),
+	}
+}
+
+// Apply replays p against s, removing p.Removes and then adding p.Adds.
+// Applied to the set the patch was computed from, the result equals the
+// patch's target.
+func (s *Set[T]) Apply(p SetPatch[T]) {
+	s.RemoveSet(p.Removes)
+	s.AddSet(p.Adds)
+}
@@ -0,0 +1,47 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchTo(t *testing.T) {
+	base := Of(1, 2, 3)
+	target := Of(2, 3, 4, 5)
+	p := base.PatchTo(target)
+	assert.Equal(t, []int{4, 5}, sorted(p.Adds.Values()))
+	assert.Equal(t, []int{1}, sorted(p.Removes.Values()))
+
+	base.Apply(p)
+	assert.True(t, base.Equal(target), "expected patched base to equal target, got %v", base)
+}
+
+func TestPatchToSelf(t *testing.T) {
+	s := Of("a", "b")
+	p := s.PatchTo(s)
+	assert.True(t, p.Adds.IsEmpty() && p.Removes.IsEmpty(), "expected empty patch against self")
+}
+
+func TestPatchApplyZeroValue(t *testing.T) {
+	var s Set[int]
+	s.Apply(Set[int]{}.PatchTo(Of(1, 2)))
+	assert.True(t, s.Equal(Of(1, 2)), "expected zero-value set to accept a patch")
+}
+
+func TestPatchJSONRoundTrip(t *testing.T) {
+	base := Of(1, 2, 3)
+	p := base.PatchTo(Of(3, 4))
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	var decoded SetPatch[int]
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	remote := Of(1, 2, 3)
+	remote.Apply(decoded)
+	assert.True(t, remote.Equal(Of(3, 4)), "expected decoded patch to reproduce target, got %v", remote)
+}
@@ -1,12 +1,20 @@
 // Package set provides a generic Set type backed by a Go map.
 package set
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
 
 // Set is an unordered collection of unique elements of type T.
-// The zero value is an empty set ready to use.
+// The zero value is an empty set ready to use. Copies of a Set that has a
+// backing map share it, so changes made through one copy are visible
+// through the others.
 type Set[T comparable] struct {
-	m map[T]struct{}
+	m    map[T]struct{}
+	grow *growHook[T] // nil unless OnGrow is pending
 }
 
 // New creates an empty set with optional initial capacity hint.
@@ -27,6 +35,27 @@ func Of[T comparable](elems ...T) Set[T] {
 	return s
 }
 
+// OfCapacity creates a set containing the given elements with the backing
+// map sized for capacity elements instead of len(elems). Use it when elems
+// holds many duplicates and the number of unique elements is known.
+func OfCapacity[T comparable](capacity int, elems ...T) Set[T] {
+	s := Set[T]{m: make(map[T]struct{}, capacity)}
+	for _, e := range elems {
+		s.m[e] = struct{}{}
+	}
+	return s
+}
+
+// OfFunc creates a set containing f(e) for each of elems, such as the set of
+// IDs of a slice of records. The backing map is sized for len(elems).
+func OfFunc[E any, T comparable](f func(E) T, elems ...E) Set[T] {
+	s := Set[T]{m: make(map[T]struct{}, len(elems))}
+	for _, e := range elems {
+		s.m[f(e)] = struct{}{}
+	}
+	return s
+}
+
 // Add inserts elem into the set. It returns true if the element was added,
 // or false if it was already present.
 func (s *Set[T]) Add(elem T) bool {
@@ -37,17 +66,55 @@ func (s *Set[T]) Add(elem T) bool {
 		return false
 	}
 	s.m[elem] = struct{}{}
+	if s.grow != nil {
+		s.checkGrow(len(s.m) - 1)
+	}
 	return true
 }
 
-// AddRange inserts one or more elements into the set.
+// AddRange inserts one or more elements into the set. A set with no backing
+// map yet allocates one sized for the whole batch. An existing map is not
+// re-allocated for a large batch but grows incrementally: copies of s share
+// it, and replacing it would leave them behind.
 func (s *Set[T]) AddRange(elems ...T) {
-	if s.m == nil {
-		s.m = make(map[T]struct{}, len(elems))
-	}
+	s.reserve(len(elems))
+	before := len(s.m)
 	for _, e := range elems {
 		s.m[e] = struct{}{}
 	}
+	if s.grow != nil {
+		s.checkGrow(before)
+	}
+}
+
+// AddAll inserts the elements of every slice in batches and returns the
+// number of elements that were newly added. A set with no backing map yet
+// allocates one sized for the combined length. As with [Set.AddRange], an
+// existing map is not pre-sized, so copies of s keep sharing it.
+func (s *Set[T]) AddAll(batches ...[]T) int {
+	total := 0
+	for _, b := range batches {
+		total += len(b)
+	}
+	s.reserve(total)
+	before := len(s.m)
+	for _, b := range batches {
+		for _, e := range b {
+			s.m[e] = struct{}{}
+		}
+	}
+	if s.grow != nil {
+		s.checkGrow(before)
+	}
+	return len(s.m) - before
+}
+
+// reserve allocates the backing map with room for n elements if s has
+// none yet. An existing map is never replaced, since copies of s share it.
+func (s *Set[T]) reserve(n int) {
+	if s.m == nil {
+		s.m = make(map[T]struct{}, n)
+	}
 }
 
 // Remove deletes one or more elements from the set.
@@ -57,6 +124,33 @@ func (s *Set[T]) Remove(elems ...T) {
 	}
 }
 
+// RemoveAll deletes elems from the set and returns those that were present,
+// in the order given. An element listed more than once is returned once.
+func (s *Set[T]) RemoveAll(elems ...T) []T {
+	removed := make([]T, 0, min(len(elems), len(s.m)))
+	for _, e := range elems {
+		if _, ok := s.m[e]; ok {
+			delete(s.m, e)
+			removed = append(removed, e)
+		}
+	}
+	return removed
+}
+
+// PopN removes and returns up to n arbitrary elements, fewer if the set holds
+// fewer than n. Which elements are returned is unspecified.
+func (s *Set[T]) PopN(n int) []T {
+	out := make([]T, 0, max(min(n, len(s.m)), 0))
+	for k := range s.m {
+		if len(out) == cap(out) {
+			break
+		}
+		delete(s.m, k)
+		out = append(out, k)
+	}
+	return out
+}
+
 // Contains reports whether the set contains elem.
 func (s Set[T]) Contains(elem T) bool {
 	_, ok := s.m[elem]
@@ -83,6 +177,38 @@ func (s Set[T]) ContainsAny(elems ...T) bool {
 	return false
 }
 
+// ContainsEach reports, for each element of elems, whether the set contains
+// it. The result is aligned with elems: out[i] is the membership of elems[i].
+func (s Set[T]) ContainsEach(elems []T) []bool {
+	out := make([]bool, len(elems))
+	for i, e := range elems {
+		_, out[i] = s.m[e]
+	}
+	return out
+}
+
+// ContainsAllSet reports whether s contains every element of other. It is
+// equivalent to [Set.IsSupersetOf].
+func (s Set[T]) ContainsAllSet(other Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// ContainsAnySet reports whether s contains at least one element of other.
+func (s Set[T]) ContainsAnySet(other Set[T]) bool {
+	return !s.IsDisjoint(other)
+}
+
+// Any returns an arbitrary element of the set without removing it, or the
+// zero value and false if the set is empty. Which element is returned is
+// unspecified and may differ between calls.
+func (s Set[T]) Any() (T, bool) {
+	for k := range s.m {
+		return k, true
+	}
+	var zero T
+	return zero, false
+}
+
 // Len returns the number of elements in the set.
 func (s Set[T]) Len() int {
 	return len(s.m)
@@ -101,6 +227,18 @@ func (s *Set[T]) Clear() {
 	clear(s.m)
 }
 
+// ClearAndShrink removes all elements from the set and releases the backing
+// map, unlike [Set.Clear] which keeps it allocated for reuse. Use it on
+// long-lived sets that briefly grew large and should give the memory back.
+//
+// Copies of s that shared the old map see it emptied as well, but they keep
+// that allocation alive until they are dropped, and s no longer shares a map
+// with them.
+func (s *Set[T]) ClearAndShrink() {
+	clear(s.m)
+	s.m = nil
+}
+
 // Clone returns a shallow copy of the set.
 func (s Set[T]) Clone() Set[T] {
 	c := Set[T]{m: make(map[T]struct{}, len(s.m))}
@@ -110,6 +248,12 @@ func (s Set[T]) Clone() Set[T] {
 	return c
 }
 
+// NewLike returns an empty set whose backing map is sized for s.Len()
+// elements, for building a result the same size as s.
+func (s Set[T]) NewLike() Set[T] {
+	return New[T](len(s.m))
+}
+
 // Values returns a slice containing all elements of the set in
 // indeterminate order.
 func (s Set[T]) Values() []T {
@@ -131,11 +275,63 @@ func (s Set[T]) All() func(yield func(T) bool) {
 	}
 }
 
+// Each calls f for each element of the set in unspecified order, stopping
+// early if f returns false. It is the callback form of [Set.All] for code
+// that does not use range-over-func.
+func (s Set[T]) Each(f func(T) bool) {
+	for k := range s.m {
+		if !f(k) {
+			return
+		}
+	}
+}
+
 // String returns a human-readable string representation of the set.
+// Every element is rendered in unspecified order; use [Set.StringN] where
+// the set may be large, such as in logs.
 func (s Set[T]) String() string {
 	return fmt.Sprintf("%v", s.Values())
 }
 
+// StringN returns a representation of at most limit elements of the set
+// followed by "...(N more)" when elements were left out, e.g.
+// "[1 2 ...(8 more)]". It is meant for logging sets of any size.
+//
+// When T's underlying type is a number or string, the smallest limit
+// elements are shown in ascending order, so the output is deterministic;
+// they are selected with a bounded heap in O(n log limit) time. For other
+// element types, including interfaces, the shown elements are an arbitrary
+// limit of them in unspecified order, found in O(limit) time.
+func (s Set[T]) StringN(limit int) string {
+	limit = min(max(limit, 0), len(s.m))
+	shown, ok := smallestOrdered(s.m, limit)
+	if !ok {
+		shown = make([]T, 0, limit)
+		for k := range s.m {
+			if len(shown) == limit {
+				break
+			}
+			shown = append(shown, k)
+		}
+	}
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, e := range shown {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, e)
+	}
+	if rest := len(s.m) - len(shown); rest > 0 {
+		if len(shown) > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "...(%d more)", rest)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
 // ---------- set-algebraic operations ----------
 
 // Union returns a new set containing all elements that are in either s or other.
@@ -159,7 +355,9 @@ func (s Set[T]) Intersection(other Set[T]) Set[T] {
 	if small.Len() > big.Len() {
 		small, big = big, small
 	}
-	out := New[T]()
+	// The result can hold at most small.Len() elements; sizing for that up
+	// front avoids rehashing as matches accumulate.
+	out := New[T](small.Len())
 	for k := range small.m {
 		if _, ok := big.m[k]; ok {
 			out.m[k] = struct{}{}
@@ -168,6 +366,42 @@ func (s Set[T]) Intersection(other Set[T]) Set[T] {
 	return out
 }
 
+// IntersectionOrdered returns the elements of order that are also in s, in
+// the order they appear in order, such as when filtering a canonical list
+// down to the members of s. Each element is returned at most once, at its
+// first occurrence in order.
+func (s Set[T]) IntersectionOrdered(order []T) []T {
+	out := make([]T, 0, min(len(order), len(s.m)))
+	seen := make(map[T]struct{}, cap(out))
+	for _, e := range order {
+		if _, ok := s.m[e]; !ok {
+			continue
+		}
+		if _, dup := seen[e]; dup {
+			continue
+		}
+		seen[e] = struct{}{}
+		out = append(out, e)
+	}
+	return out
+}
+
+// IntersectionCount returns the number of elements present in both s and
+// other without building the intersection.
+func (s Set[T]) IntersectionCount(other Set[T]) int {
+	small, big := s, other
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+	n := 0
+	for k := range small.m {
+		if _, ok := big.m[k]; ok {
+			n++
+		}
+	}
+	return n
+}
+
 // Difference returns a new set containing elements in s that are not in other.
 func (s Set[T]) Difference(other Set[T]) Set[T] {
 	out := New[T]()
@@ -179,6 +413,37 @@ func (s Set[T]) Difference(other Set[T]) Set[T] {
 	return out
 }
 
+// DifferenceSlice returns a new set containing the elements of s that do not
+// appear in exclude. It copies s and deletes each excluded element from the
+// copy, so no set is built from exclude whatever its length, and the cost is
+// O(len(s) + len(exclude)).
+func (s Set[T]) DifferenceSlice(exclude []T) Set[T] {
+	out := s.Clone()
+	for _, e := range exclude {
+		delete(out.m, e)
+	}
+	return out
+}
+
+// DifferencePred returns a new set containing the elements of s for which
+// pred returns false, treating pred as a rule that defines the set to
+// subtract.
+func (s Set[T]) DifferencePred(pred func(T) bool) Set[T] {
+	out := New[T]()
+	for k := range s.m {
+		if !pred(k) {
+			out.m[k] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Complement returns a new set containing the elements of universe that are
+// not in s. Elements of s that are not in universe are ignored.
+func (s Set[T]) Complement(universe Set[T]) Set[T] {
+	return universe.Difference(s)
+}
+
 // SymmetricDifference returns a new set containing elements that are in
 // exactly one of s or other.
 func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
@@ -196,6 +461,24 @@ func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
 	return out
 }
 
+// SymmetricDifferenceTagged is like [Set.SymmetricDifference] but returns the
+// two halves separately: leftOnly holds the elements only in s and rightOnly
+// holds the elements only in other.
+func (s Set[T]) SymmetricDifferenceTagged(other Set[T]) (leftOnly, rightOnly Set[T]) {
+	leftOnly, rightOnly = New[T](), New[T]()
+	for k := range s.m {
+		if _, ok := other.m[k]; !ok {
+			leftOnly.m[k] = struct{}{}
+		}
+	}
+	for k := range other.m {
+		if _, ok := s.m[k]; !ok {
+			rightOnly.m[k] = struct{}{}
+		}
+	}
+	return leftOnly, rightOnly
+}
+
 // IsSubsetOf reports whether every element of s is also in other.
 func (s Set[T]) IsSubsetOf(other Set[T]) bool {
 	if s.Len() > other.Len() {
@@ -229,6 +512,9 @@ func (s Set[T]) Equal(other Set[T]) bool {
 	if s.Len() != other.Len() {
 		return false
 	}
+	if s.SameBacking(other) {
+		return true
+	}
 	for k := range s.m {
 		if _, ok := other.m[k]; !ok {
 			return false
@@ -237,6 +523,58 @@ func (s Set[T]) Equal(other Set[T]) bool {
 	return true
 }
 
+// SameBacking reports whether s and other share the same backing map, as
+// copies of one Set value do. Sets that share a backing map are always
+// equal, and [Set.Equal] uses this as its fast path. A false result
+// says nothing about equality: clones and independently built sets have
+// distinct maps. A set with no map yet, such as the zero value, shares
+// with nothing. The check can go stale, because [Set.ClearAndShrink] and
+// [Set.UnmarshalJSON] give the receiver a new map.
+func (s Set[T]) SameBacking(other Set[T]) bool {
+	if s.m == nil || other.m == nil {
+		return false
+	}
+	return reflect.ValueOf(s.m).UnsafePointer() == reflect.ValueOf(other.m).UnsafePointer()
+}
+
+// EqualSeq reports whether seq yields exactly the elements of s, each once.
+// It stops at the first element that is not in s or that repeats, so a
+// mismatching stream is not consumed further than necessary.
+func (s Set[T]) EqualSeq(seq iter.Seq[T]) bool {
+	seen := make(map[T]struct{}, len(s.m))
+	for e := range seq {
+		if _, ok := s.m[e]; !ok {
+			return false
+		}
+		if _, dup := seen[e]; dup {
+			return false
+		}
+		seen[e] = struct{}{}
+	}
+	return len(seen) == len(s.m)
+}
+
+// EqualIgnoring reports whether s and other contain the same elements once
+// every element of ignore is disregarded. Neither set is modified.
+func (s Set[T]) EqualIgnoring(other, ignore Set[T]) bool {
+	n := 0
+	for k := range s.m {
+		if _, skip := ignore.m[k]; skip {
+			continue
+		}
+		if _, ok := other.m[k]; !ok {
+			return false
+		}
+		n++
+	}
+	for k := range other.m {
+		if _, skip := ignore.m[k]; !skip {
+			n--
+		}
+	}
+	return n == 0
+}
+
 // IsDisjoint reports whether s and other share no elements.
 func (s Set[T]) IsDisjoint(other Set[T]) bool {
 	small, big := s, other
@@ -251,6 +589,27 @@ func (s Set[T]) IsDisjoint(other Set[T]) bool {
 	return true
 }
 
+// DisjointAll reports whether no element appears in more than one of sets,
+// such as when validating that they partition a domain. It makes one pass
+// over all elements, recording each in a running set and stopping at the
+// first element already seen, rather than comparing every pair of sets.
+func DisjointAll[T comparable](sets ...Set[T]) bool {
+	total := 0
+	for _, s := range sets {
+		total += len(s.m)
+	}
+	seen := make(map[T]struct{}, total)
+	for _, s := range sets {
+		for k := range s.m {
+			if _, ok := seen[k]; ok {
+				return false
+			}
+			seen[k] = struct{}{}
+		}
+	}
+	return true
+}
+
 // ---------- in-place mutating operations ----------
 
 // AddSet adds all elements from other into s.
@@ -261,9 +620,13 @@ func (s *Set[T]) AddSet(other Set[T]) {
 	if s.m == nil {
 		s.m = make(map[T]struct{}, len(other.m))
 	}
+	before := len(s.m)
 	for k := range other.m {
 		s.m[k] = struct{}{}
 	}
+	if s.grow != nil {
+		s.checkGrow(before)
+	}
 }
 
 // RemoveSet removes all elements of other from s.
@@ -282,6 +645,35 @@ func (s *Set[T]) RemoveSet(other Set[T]) {
 	}
 }
 
+// SubtractReturning removes all elements of other from s, like
+// [Set.RemoveSet], and returns the elements that were actually removed, in
+// unspecified order. Like RemoveSet, it iterates whichever set is smaller.
+func (s *Set[T]) SubtractReturning(other Set[T]) []T {
+	removed := make([]T, 0, min(len(s.m), len(other.m)))
+	small, big := *s, other
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+	for k := range small.m {
+		if _, ok := big.m[k]; ok {
+			removed = append(removed, k)
+		}
+	}
+	for _, k := range removed {
+		delete(s.m, k)
+	}
+	return removed
+}
+
+// IntersectionUpdate removes every element from s that is not in other, like
+// [Set.RetainAll], and reports whether any element was removed. This lets
+// fixpoint loops detect convergence without comparing lengths.
+func (s *Set[T]) IntersectionUpdate(other Set[T]) bool {
+	before := len(s.m)
+	s.RetainAll(other)
+	return len(s.m) != before
+}
+
 // RetainAll removes every element from s that is not in other.
 func (s *Set[T]) RetainAll(other Set[T]) {
 	for k := range s.m {
@@ -32,6 +32,30 @@ func TestOf(t *testing.T) {
 	}
 }
 
+func TestOfCapacity(t *testing.T) {
+	s := OfCapacity(2, 1, 1, 1, 2, 2, 2)
+	require.Equal(t, 2, s.Len(), "expected 2 unique elements")
+	assert.True(t, s.ContainsAll(1, 2))
+
+	s = OfCapacity(1, 1, 2, 3)
+	assert.Equal(t, 3, s.Len(), "expected capacity to be a hint only")
+}
+
+func TestOfFunc(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{1, "ann"}, {2, "bob"}, {1, "ann again"}}
+	ids := OfFunc(func(u user) int { return u.ID }, users...)
+	assert.Equal(t, []int{1, 2}, sorted(ids.Values()))
+
+	empty := OfFunc(func(s string) int { return len(s) })
+	assert.True(t, empty.IsEmpty())
+	empty.Add(1)
+	assert.True(t, empty.Contains(1), "expected set from no inputs to be usable")
+}
+
 func TestAddRemoveContains(t *testing.T) {
 	s := New[string]()
 	assert.True(t, s.Add("a"), "expected Add to return true for new element")
@@ -53,6 +77,63 @@ func TestAddRange(t *testing.T) {
 	assert.True(t, s.ContainsAll(1, 2, 3), "expected set to contain all added elements")
 }
 
+func TestAddRangeLargeBatch(t *testing.T) {
+	s := Of(-1, -2)
+	batch := make([]int, 1000)
+	for i := range batch {
+		batch[i] = i
+	}
+	s.AddRange(batch...)
+	require.Equal(t, 1002, s.Len(), "expected existing and batch elements")
+	assert.True(t, s.ContainsAll(-1, -2, 0, 999), "expected set to keep old elements and gain new ones")
+}
+
+func TestLargeBatchAddsKeepAliases(t *testing.T) {
+	s := Of(-1)
+	alias := s
+	s.AddRange(1, 2, 3, 4, 5, 6, 7)
+	assert.Equal(t, 8, alias.Len(), "expected a copy to share the map after a large AddRange")
+	assert.True(t, alias.Contains(7))
+
+	alias2 := s
+	s.AddAll([]int{10, 11, 12, 13}, []int{14, 15, 16, 17, 18})
+	assert.Equal(t, 17, alias2.Len(), "expected a copy to share the map after a large AddAll")
+	assert.True(t, alias2.Contains(18))
+}
+
+func TestAddAll(t *testing.T) {
+	s := Of(1)
+	added := s.AddAll([]int{1, 2, 3}, nil, []int{3, 4}, []int{5})
+	assert.Equal(t, 4, added, "expected 4 newly added elements")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, sorted(s.Values()))
+
+	var z Set[string]
+	assert.Equal(t, 2, z.AddAll([]string{"a"}, []string{"b", "a"}))
+	assert.Equal(t, 0, z.AddAll(), "expected AddAll with no batches to add nothing")
+}
+
+func TestPopN(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	popped := s.PopN(2)
+	require.Len(t, popped, 2)
+	assert.Equal(t, 3, s.Len(), "expected popped elements to be removed")
+	for _, v := range popped {
+		assert.False(t, s.Contains(v), "expected %d to be removed", v)
+	}
+
+	rest := s.PopN(10)
+	assert.Len(t, rest, 3, "expected PopN to return only what is left")
+	assert.True(t, s.IsEmpty())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, sorted(append(popped, rest...)))
+
+	one := Of(1)
+	assert.Empty(t, one.PopN(0))
+	assert.Empty(t, one.PopN(-1))
+	assert.Equal(t, 1, one.Len())
+	var z Set[int]
+	assert.Empty(t, z.PopN(3))
+}
+
 func TestContainsAll(t *testing.T) {
 	s := Of(1, 2, 3, 4, 5)
 	assert.True(t, s.ContainsAll(1, 3, 5), "expected ContainsAll to return true for subset")
@@ -65,6 +146,40 @@ func TestContainsAny(t *testing.T) {
 	assert.False(t, s.ContainsAny(7, 8), "expected ContainsAny to return false")
 }
 
+func TestContainsEach(t *testing.T) {
+	s := Of(1, 3)
+	assert.Equal(t, []bool{true, false, true, true, false}, s.ContainsEach([]int{1, 2, 3, 1, 4}))
+	assert.Empty(t, s.ContainsEach(nil))
+	assert.Equal(t, []bool{false}, Set[int]{}.ContainsEach([]int{1}))
+}
+
+func TestContainsAllSet(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	assert.True(t, s.ContainsAllSet(Of(1, 3, 5)), "expected ContainsAllSet to return true for subset")
+	assert.False(t, s.ContainsAllSet(Of(1, 6)), "expected ContainsAllSet to return false when an element is missing")
+	assert.True(t, s.ContainsAllSet(Set[int]{}), "expected every set to contain the empty set")
+}
+
+func TestContainsAnySet(t *testing.T) {
+	s := Of(1, 2, 3)
+	assert.True(t, s.ContainsAnySet(Of(5, 3)), "expected ContainsAnySet to return true")
+	assert.False(t, s.ContainsAnySet(Of(7, 8)), "expected ContainsAnySet to return false")
+	assert.False(t, s.ContainsAnySet(Set[int]{}), "expected ContainsAnySet on empty set to return false")
+}
+
+func TestAny(t *testing.T) {
+	s := Of(1, 2, 3)
+	v, ok := s.Any()
+	assert.True(t, ok, "expected Any to find an element")
+	assert.True(t, s.Contains(v), "expected Any to return a member, got %d", v)
+	assert.Equal(t, 3, s.Len(), "expected Any not to remove the element")
+
+	var empty Set[int]
+	v, ok = empty.Any()
+	assert.False(t, ok, "expected Any on empty set to return false")
+	assert.Zero(t, v)
+}
+
 func TestIsEmpty(t *testing.T) {
 	s := New[int]()
 	assert.True(t, s.IsEmpty(), "expected new set to be empty")
@@ -78,6 +193,19 @@ func TestClear(t *testing.T) {
 	require.Equal(t, 0, s.Len(), "expected empty set after clear")
 }
 
+func TestClearAndShrink(t *testing.T) {
+	s := New[int](1000)
+	for i := range 1000 {
+		s.Add(i)
+	}
+	alias := s
+	s.ClearAndShrink()
+	assert.Equal(t, 0, alias.Len(), "expected copies to see the set emptied")
+	require.Equal(t, 0, s.Len(), "expected empty set after ClearAndShrink")
+	assert.True(t, s.Add(1), "expected set to be usable after ClearAndShrink")
+	assert.True(t, s.Contains(1))
+}
+
 func TestClone(t *testing.T) {
 	s := Of(1, 2, 3)
 	c := s.Clone()
@@ -86,6 +214,15 @@ func TestClone(t *testing.T) {
 	assert.False(t, s.Contains(4), "mutating clone should not affect original")
 }
 
+func TestNewLike(t *testing.T) {
+	s := Of(1, 2, 3)
+	n := s.NewLike()
+	assert.True(t, n.IsEmpty(), "expected NewLike to return an empty set")
+	n.Add(4)
+	assert.False(t, s.Contains(4), "expected NewLike to be independent of s")
+	assert.True(t, Set[int]{}.NewLike().IsEmpty())
+}
+
 func TestValues(t *testing.T) {
 	s := Of(3, 1, 2)
 	vals := sorted(s.Values())
@@ -102,6 +239,27 @@ func TestAll(t *testing.T) {
 	assert.Equal(t, 3, len(collected), "expected 3 elements from iterator")
 }
 
+func TestEach(t *testing.T) {
+	var got []int
+	Of(1, 2, 3).Each(func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3}, sorted(got))
+
+	count := 0
+	Of(1, 2, 3, 4, 5).Each(func(int) bool {
+		count++
+		return count < 2
+	})
+	assert.Equal(t, 2, count, "expected Each to stop when f returns false")
+
+	Set[int]{}.Each(func(int) bool {
+		t.Fatal("f must not be called on an empty set")
+		return true
+	})
+}
+
 func TestAllEarlyBreak(t *testing.T) {
 	s := Of(1, 2, 3, 4, 5)
 	count := 0
@@ -120,6 +278,34 @@ func TestString(t *testing.T) {
 	assert.Equal(t, "[42]", str)
 }
 
+func TestStringN(t *testing.T) {
+	s := New[int]()
+	for i := 10; i >= 1; i-- {
+		s.Add(i)
+	}
+	assert.Equal(t, "[1 2 ...(8 more)]", s.StringN(2))
+	assert.Equal(t, "[...(10 more)]", s.StringN(0))
+	assert.Equal(t, "[...(10 more)]", s.StringN(-1))
+	assert.Equal(t, "[1 2 3 4 5 6 7 8 9 10]", s.StringN(100), "expected numeric, not lexical, order")
+	assert.Equal(t, "[]", Set[int]{}.StringN(3))
+
+	assert.Equal(t, "[a b ...(1 more)]", Of("c", "b", "a").StringN(2))
+	type celsius int8
+	assert.Equal(t, "[-3 -1 ...(1 more)]", Of[celsius](2, -1, -3).StringN(2), "expected named types to sort by their underlying kind")
+	assert.Equal(t, "[1 200 ...(1 more)]", Of[uint8](255, 1, 200).StringN(2))
+	assert.Equal(t, "[-0.5 1.5 ...(1 more)]", Of(2.5, 1.5, -0.5).StringN(2))
+
+	// Element types that are not ordered are shown unsorted.
+	type point struct{ X, Y int }
+	str := Of(point{3, 4}, point{1, 2}, point{5, 6}).StringN(2)
+	assert.Regexp(t, `^\[\{\d \d\} \{\d \d\} \.\.\.\(1 more\)\]$`, str)
+	str = Of[any]("x", 10, nil).StringN(5)
+	assert.Len(t, str, len("[x 10 <nil>]"))
+	for _, e := range []string{"x", "10", "<nil>"} {
+		assert.Contains(t, str, e)
+	}
+}
+
 // ---------- set operations ----------
 
 func TestUnion(t *testing.T) {
@@ -154,6 +340,23 @@ func TestIntersectionEmpty(t *testing.T) {
 	assert.True(t, inter.IsEmpty(), "expected empty intersection")
 }
 
+func TestIntersectionOrdered(t *testing.T) {
+	s := Of("b", "d", "a", "z")
+	order := []string{"a", "b", "c", "d", "b", "a", "e"}
+	assert.Equal(t, []string{"a", "b", "d"}, s.IntersectionOrdered(order))
+	assert.Equal(t, []string{"d", "a"}, s.IntersectionOrdered([]string{"d", "x", "a", "d"}))
+	assert.Empty(t, s.IntersectionOrdered(nil))
+	assert.Empty(t, Set[string]{}.IntersectionOrdered(order))
+}
+
+func TestIntersectionCount(t *testing.T) {
+	a := Of(1, 2, 3, 4)
+	b := Of(3, 4, 5)
+	assert.Equal(t, 2, a.IntersectionCount(b))
+	assert.Equal(t, 2, b.IntersectionCount(a))
+	assert.Equal(t, 0, a.IntersectionCount(Set[int]{}))
+}
+
 func TestDifference(t *testing.T) {
 	a := Of(1, 2, 3, 4)
 	b := Of(3, 4, 5)
@@ -162,6 +365,31 @@ func TestDifference(t *testing.T) {
 	assert.True(t, slices.Equal(sorted(diff.Values()), expected), "Difference: expected %v, got %v", expected, sorted(diff.Values()))
 }
 
+func TestDifferenceSlice(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	d := s.DifferenceSlice([]int{2, 4, 4, 99})
+	assert.Equal(t, []int{1, 3, 5}, sorted(d.Values()))
+	assert.Equal(t, 5, s.Len(), "expected original set to be unchanged")
+	assert.True(t, s.DifferenceSlice(nil).Equal(s))
+	assert.True(t, Set[int]{}.DifferenceSlice([]int{1}).IsEmpty())
+}
+
+func TestDifferencePred(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	odd := s.DifferencePred(func(n int) bool { return n%2 == 0 })
+	assert.Equal(t, []int{1, 3, 5}, sorted(odd.Values()))
+	assert.Equal(t, 5, s.Len(), "expected original set to be unchanged")
+	assert.True(t, Set[int]{}.DifferencePred(func(int) bool { return false }).IsEmpty())
+}
+
+func TestComplement(t *testing.T) {
+	universe := Of(1, 2, 3, 4, 5)
+	selected := Of(2, 4, 99)
+	assert.Equal(t, []int{1, 3, 5}, sorted(selected.Complement(universe).Values()))
+	assert.True(t, Set[int]{}.Complement(universe).Equal(universe))
+	assert.True(t, universe.Complement(universe).IsEmpty())
+}
+
 func TestSymmetricDifference(t *testing.T) {
 	a := Of(1, 2, 3)
 	b := Of(3, 4, 5)
@@ -170,6 +398,17 @@ func TestSymmetricDifference(t *testing.T) {
 	assert.True(t, slices.Equal(sorted(sd.Values()), expected), "SymmetricDifference: expected %v, got %v", expected, sorted(sd.Values()))
 }
 
+func TestSymmetricDifferenceTagged(t *testing.T) {
+	a := Of(1, 2, 3, 4)
+	b := Of(3, 4, 5)
+	left, right := a.SymmetricDifferenceTagged(b)
+	assert.Equal(t, []int{1, 2}, sorted(left.Values()))
+	assert.Equal(t, []int{5}, sorted(right.Values()))
+
+	left, right = a.SymmetricDifferenceTagged(a)
+	assert.True(t, left.IsEmpty() && right.IsEmpty(), "expected no differences against self")
+}
+
 func TestIsSubsetOf(t *testing.T) {
 	a := Of(1, 2)
 	b := Of(1, 2, 3, 4)
@@ -211,6 +450,48 @@ func TestEqual(t *testing.T) {
 	assert.False(t, a.Equal(b), "expected unequal sets after adding element")
 }
 
+func TestSameBacking(t *testing.T) {
+	s := Of(1, 2, 3)
+	alias := s
+	assert.True(t, s.SameBacking(alias), "expected copies to share a backing map")
+	assert.False(t, s.SameBacking(s.Clone()), "expected clones to have distinct maps")
+	assert.False(t, s.SameBacking(Of(1, 2, 3)), "expected independent sets to have distinct maps")
+	assert.False(t, s.SameBacking(Set[int]{}))
+	assert.False(t, Set[int]{}.SameBacking(Set[int]{}), "expected independent zero-value sets not to share")
+	assert.True(t, Set[int]{}.Equal(Set[int]{}))
+}
+
+func TestEqualSeq(t *testing.T) {
+	s := Of(1, 2, 3)
+	assert.True(t, s.EqualSeq(slices.Values([]int{3, 1, 2})), "expected permutation to match")
+	assert.False(t, s.EqualSeq(slices.Values([]int{1, 2})), "expected missing element to fail")
+	assert.False(t, s.EqualSeq(slices.Values([]int{1, 2, 3, 4})), "expected extra element to fail")
+	assert.False(t, s.EqualSeq(slices.Values([]int{1, 2, 2, 3})), "expected duplicate to fail")
+	assert.True(t, Set[int]{}.EqualSeq(slices.Values([]int(nil))), "expected empty sequences to match")
+
+	pulled := 0
+	s.EqualSeq(func(yield func(int) bool) {
+		for _, v := range []int{1, 9, 2, 3} {
+			pulled++
+			if !yield(v) {
+				return
+			}
+		}
+	})
+	assert.Equal(t, 2, pulled, "expected EqualSeq to stop at the first unexpected element")
+}
+
+func TestEqualIgnoring(t *testing.T) {
+	a := Of(1, 2, 3, 100)
+	b := Of(1, 2, 3, 200)
+	assert.True(t, a.EqualIgnoring(b, Of(100, 200)), "expected sets to be equal ignoring 100 and 200")
+	assert.False(t, a.EqualIgnoring(b, Of(100)), "expected sets to differ when 200 is not ignored")
+	assert.False(t, a.EqualIgnoring(Of(1, 2), Of(100)), "expected sets to differ when other is missing 3")
+	assert.False(t, Of(1, 2).EqualIgnoring(a, Of(100)), "expected sets to differ when s is missing 3")
+	assert.True(t, a.EqualIgnoring(a, Set[int]{}), "expected set to equal itself with empty ignore set")
+	assert.Equal(t, 4, a.Len(), "expected original set to be unchanged")
+}
+
 func TestIsDisjoint(t *testing.T) {
 	a := Of(1, 2)
 	b := Of(3, 4)
@@ -219,6 +500,15 @@ func TestIsDisjoint(t *testing.T) {
 	assert.False(t, a.IsDisjoint(b), "expected non-disjoint sets")
 }
 
+func TestDisjointAll(t *testing.T) {
+	assert.True(t, DisjointAll(Of(1, 2), Of(3), Set[int]{}, Of(4, 5)))
+	assert.False(t, DisjointAll(Of(1, 2), Of(3), Of(4, 2)), "expected overlap between first and last sets")
+	a := Of(1)
+	assert.False(t, DisjointAll(a, a), "expected a non-empty set to overlap itself")
+	assert.True(t, DisjointAll[int]())
+	assert.True(t, DisjointAll(Of(1, 2, 3)))
+}
+
 // ---------- in-place mutations ----------
 
 func TestAddSet(t *testing.T) {
@@ -229,6 +519,15 @@ func TestAddSet(t *testing.T) {
 	assert.True(t, slices.Equal(sorted(a.Values()), expected), "AddSet: expected %v, got %v", expected, sorted(a.Values()))
 }
 
+func TestRemoveAll(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	assert.Equal(t, []int{3, 1}, s.RemoveAll(3, 9, 1, 3))
+	assert.Equal(t, []int{2, 4}, sorted(s.Values()))
+
+	var z Set[int]
+	assert.Empty(t, z.RemoveAll(1, 2))
+}
+
 func TestRemoveSet(t *testing.T) {
 	a := Of(1, 2, 3, 4)
 	b := Of(2, 4)
@@ -237,6 +536,23 @@ func TestRemoveSet(t *testing.T) {
 	assert.True(t, slices.Equal(sorted(a.Values()), expected), "RemoveSet: expected %v, got %v", expected, sorted(a.Values()))
 }
 
+func TestSubtractReturning(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	assert.Equal(t, []int{2, 4}, sorted(s.SubtractReturning(Of(2, 4, 6))))
+	assert.Equal(t, []int{1, 3}, sorted(s.Values()))
+
+	big := New[int]()
+	for i := range 100 {
+		big.Add(i)
+	}
+	assert.Equal(t, []int{1, 3}, sorted(s.SubtractReturning(big)), "expected the smaller receiver to be iterated correctly")
+	assert.True(t, s.IsEmpty())
+	assert.Equal(t, 100, big.Len(), "other should be unaffected")
+
+	var z Set[int]
+	assert.Empty(t, z.SubtractReturning(Of(1)))
+}
+
 func TestRemoveSetLargerOther(t *testing.T) {
 	// Exercise the branch where |other| > |s|.
 	a := Of(1, 2)
@@ -253,6 +569,16 @@ func TestRetainAll(t *testing.T) {
 	assert.True(t, slices.Equal(sorted(a.Values()), expected), "RetainAll: expected %v, got %v", expected, sorted(a.Values()))
 }
 
+func TestIntersectionUpdate(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	assert.True(t, s.IntersectionUpdate(Of(2, 4, 6)), "expected change when elements are removed")
+	assert.Equal(t, []int{2, 4}, sorted(s.Values()))
+	assert.False(t, s.IntersectionUpdate(Of(2, 4, 6)), "expected no change at the fixpoint")
+
+	var z Set[int]
+	assert.False(t, z.IntersectionUpdate(Of(1)))
+}
+
 // ---------- edge cases ----------
 
 func TestEmptySetOperations(t *testing.T) {
@@ -414,6 +740,57 @@ func BenchmarkIntersection(b *testing.B) {
 	}
 }
 
+func BenchmarkIntersectionLargeOverlap(b *testing.B) {
+	a := New[int](100_000)
+	c := New[int](100_000)
+	for i := range 100_000 {
+		a.Add(i)
+		c.Add(i + 10_000)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		a.Intersection(c)
+	}
+}
+
+func BenchmarkIntersectionCount(b *testing.B) {
+	a := New[int](1000)
+	c := New[int](1000)
+	for i := range 1000 {
+		a.Add(i)
+		c.Add(i + 500)
+	}
+	b.ResetTimer()
+	for range b.N {
+		a.IntersectionCount(c)
+	}
+}
+
+func BenchmarkEqual(b *testing.B) {
+	a := New[int](1000)
+	for i := range 1000 {
+		a.Add(i)
+	}
+	c := a.Clone()
+	b.ResetTimer()
+	for range b.N {
+		a.Equal(c)
+	}
+}
+
+func BenchmarkEqualAliased(b *testing.B) {
+	a := New[int](1000)
+	for i := range 1000 {
+		a.Add(i)
+	}
+	c := a
+	b.ResetTimer()
+	for range b.N {
+		a.Equal(c)
+	}
+}
+
 func BenchmarkDifference(b *testing.B) {
 	a := New[int](1000)
 	c := New[int](1000)
@@ -426,3 +803,45 @@ func BenchmarkDifference(b *testing.B) {
 		a.Difference(c)
 	}
 }
+
+// BenchmarkAddRangeLargeBatch measures a batch much larger than the set,
+// the case where re-allocating the map up front would help. That was not
+// adopted because it would stop copies sharing the map.
+func BenchmarkAddRangeLargeBatch(b *testing.B) {
+	batch := make([]int, 100_000)
+	for i := range batch {
+		batch[i] = i
+	}
+	b.ResetTimer()
+	for range b.N {
+		s := Of(-1, -2, -3)
+		s.AddRange(batch...)
+	}
+}
+
+// The small-set benchmarks are a baseline for an inline small-set
+// representation, which was not adopted: Set copies share their backing map
+// (see Set and TestLargeBatchAddsKeepAliases), and an inline array would
+// break that sharing.
+func BenchmarkSmallOf(b *testing.B) {
+	for range b.N {
+		Of(1, 2, 3, 4, 5, 6)
+	}
+}
+
+func BenchmarkSmallContains(b *testing.B) {
+	s := Of(1, 2, 3, 4, 5, 6)
+	b.ResetTimer()
+	for i := range b.N {
+		s.Contains(i & 7)
+	}
+}
+
+func BenchmarkSmallUnion(b *testing.B) {
+	a := Of(1, 2, 3)
+	c := Of(3, 4, 5)
+	b.ResetTimer()
+	for range b.N {
+		a.Union(c)
+	}
+}
@@ -0,0 +1,48 @@
+// Package settest provides helpers for testing code that serializes
+// [set.Set] values, such as sets of custom element types.
+package settest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wow-look-at-my/go-containers/set"
+)
+
+// AssertRoundTrip serializes s and reads it back through each encoding the
+// set package supports: [encoding/json] via MarshalJSON/UnmarshalJSON, and
+// the streaming [set.Set.EncodeJSON]/[set.DecodeJSON] pair. It returns an
+// error naming the encoding and the missing and unexpected elements if any
+// round trip does not reproduce s, or nil if all of them do.
+func AssertRoundTrip[T comparable](s set.Set[T]) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("settest: json.Marshal: %w", err)
+	}
+	var restored set.Set[T]
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return fmt.Errorf("settest: json.Unmarshal: %w", err)
+	}
+	if err := compare("json.Marshal", s, restored); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := s.EncodeJSON(&buf); err != nil {
+		return fmt.Errorf("settest: EncodeJSON: %w", err)
+	}
+	restored, err = set.DecodeJSON[T](&buf)
+	if err != nil {
+		return fmt.Errorf("settest: DecodeJSON: %w", err)
+	}
+	return compare("EncodeJSON", s, restored)
+}
+
+func compare[T comparable](encoding string, want, got set.Set[T]) error {
+	if want.Equal(got) {
+		return nil
+	}
+	return fmt.Errorf("settest: %s round trip mismatch: missing %v, unexpected %v",
+		encoding, want.Difference(got), got.Difference(want))
+}
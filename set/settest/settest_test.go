@@ -0,0 +1,45 @@
+package settest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wow-look-at-my/go-containers/set"
+)
+
+type point struct {
+	X, Y int
+}
+
+// lossy drops its Y field when serialized, so sets of it do not round-trip.
+type lossy struct {
+	X int
+	Y int `json:"-"`
+}
+
+type broken struct{}
+
+func (broken) MarshalJSON() ([]byte, error) { return nil, assert.AnError }
+
+func TestAssertRoundTrip(t *testing.T) {
+	assert.NoError(t, AssertRoundTrip(set.Of(1, 2, 3)))
+	assert.NoError(t, AssertRoundTrip(set.Of("a", "b")))
+	assert.NoError(t, AssertRoundTrip(set.Of(point{1, 2}, point{3, 4})))
+	assert.NoError(t, AssertRoundTrip(set.Set[int]{}))
+}
+
+func TestAssertRoundTripMismatch(t *testing.T) {
+	err := AssertRoundTrip(set.Of(lossy{1, 2}))
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "mismatch"), "unexpected error: %v", err)
+	}
+}
+
+func TestAssertRoundTripMarshalError(t *testing.T) {
+	err := AssertRoundTrip(set.Of(broken{}))
+	assert.ErrorIs(t, err, assert.AnError)
+	var me *json.MarshalerError
+	assert.ErrorAs(t, err, &me)
+}
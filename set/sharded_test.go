@@ -0,0 +1,85 @@
+package set
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharded(t *testing.T) {
+	s := NewSharded[int](8)
+	assert.True(t, s.Add(1))
+	assert.False(t, s.Add(1), "expected Add to return false for duplicate element")
+	s.Add(2)
+	s.Add(3)
+	assert.True(t, s.Contains(2))
+	assert.Equal(t, 3, s.Len())
+
+	s.Remove(2, 99)
+	assert.False(t, s.Contains(2))
+	assert.Equal(t, 2, s.Len())
+
+	assert.Panics(t, func() { NewSharded[int](0) })
+}
+
+func TestShardedConcurrent(t *testing.T) {
+	s := NewSharded[int](16)
+	var wg sync.WaitGroup
+	var added atomic.Int64
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Goroutines overlap on half their range, so some Adds lose.
+			for i := range 1000 {
+				if s.Add(g%2*500 + i) {
+					added.Add(1)
+				}
+				s.Contains(i)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1500, s.Len())
+	assert.Equal(t, int64(1500), added.Load(), "expected each element to be reported as added exactly once")
+}
+
+// lockedSet is the single-mutex baseline for the Sharded benchmarks.
+type lockedSet[T comparable] struct {
+	mu  sync.Mutex
+	set Set[T]
+}
+
+func (l *lockedSet[T]) Add(elem T) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.set.Add(elem)
+}
+
+func BenchmarkParallelAddMutex(b *testing.B) {
+	var s lockedSet[int]
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		base := int(next.Add(1)) << 32
+		i := 0
+		for pb.Next() {
+			s.Add(base + i%100_000)
+			i++
+		}
+	})
+}
+
+func BenchmarkParallelAddSharded(b *testing.B) {
+	s := NewSharded[int](64)
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		base := int(next.Add(1)) << 32
+		i := 0
+		for pb.Next() {
+			s.Add(base + i%100_000)
+			i++
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package set
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/wow-look-at-my/go-containers/sortedmap"
+)
+
+// ToSortedMap returns a [sortedmap.SortedMap] keyed by the elements of s, with
+// each value computed by calling value on its key. The map is bulk-loaded
+// from the sorted elements rather than built by repeated Puts.
+//
+// Elements that cmp.Compare treats as equal become a single key, since the
+// map cannot hold both: a Set[float64] may contain several NaNs, which are
+// distinct map keys but compare equal, and they yield one NaN entry.
+func ToSortedMap[T cmp.Ordered, V any](s Set[T], value func(T) V) *sortedmap.SortedMap[T, V] {
+	keys := s.Values()
+	slices.Sort(keys)
+	keys = slices.CompactFunc(keys, func(a, b T) bool { return cmp.Compare(a, b) == 0 })
+	entries := make([]sortedmap.Entry[T, V], len(keys))
+	for i, k := range keys {
+		entries[i] = sortedmap.Entry[T, V]{Key: k, Value: value(k)}
+	}
+	return sortedmap.FromSorted(entries)
+}
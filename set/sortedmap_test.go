@@ -0,0 +1,42 @@
+package set
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSortedMap(t *testing.T) {
+	s := Of(30, 10, 20)
+	m := ToSortedMap(s, strconv.Itoa)
+
+	assert.Equal(t, 3, m.Len(), "expected 3 entries")
+	var keys []int
+	var vals []string
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	}
+	assert.Equal(t, []int{10, 20, 30}, keys)
+	assert.Equal(t, []string{"10", "20", "30"}, vals)
+}
+
+func TestToSortedMapEmpty(t *testing.T) {
+	var s Set[string]
+	m := ToSortedMap(s, func(string) int { return 0 })
+	assert.True(t, m.IsEmpty(), "expected empty map from zero-value set")
+	m.Put("a", 1)
+	assert.Equal(t, 1, m.Len(), "expected map to be usable after conversion")
+}
+
+func TestToSortedMapNaN(t *testing.T) {
+	s := Of(1.5, math.NaN(), math.NaN())
+	require.Equal(t, 3, s.Len(), "NaNs are distinct map keys")
+	m := ToSortedMap(s, func(float64) int { return 1 })
+	assert.Equal(t, 2, m.Len(), "expected NaNs to collapse to one key")
+	k, _, ok := m.Min()
+	assert.True(t, ok && math.IsNaN(k), "expected NaN to sort first")
+}
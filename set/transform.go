@@ -0,0 +1,13 @@
+package set
+
+// MapCounting returns the set of f(e) for every element e of s, along with
+// the number of collisions: elements whose image was already produced by
+// another element. It equals s.Len() minus the size of the result, and
+// measures how lossy the projection was.
+func MapCounting[T, U comparable](s Set[T], f func(T) U) (Set[U], int) {
+	out := New[U](len(s.m))
+	for k := range s.m {
+		out.m[f(k)] = struct{}{}
+	}
+	return out, len(s.m) - len(out.m)
+}
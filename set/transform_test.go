@@ -0,0 +1,22 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapCounting(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6)
+	parity, collisions := MapCounting(s, func(n int) bool { return n%2 == 0 })
+	assert.True(t, parity.Equal(Of(true, false)))
+	assert.Equal(t, 4, collisions)
+
+	doubled, collisions := MapCounting(s, func(n int) int { return n * 2 })
+	assert.Equal(t, []int{2, 4, 6, 8, 10, 12}, sorted(doubled.Values()))
+	assert.Equal(t, 0, collisions, "expected no collisions for an injective mapping")
+
+	empty, collisions := MapCounting(Set[int]{}, func(n int) string { return "" })
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, 0, collisions)
+}
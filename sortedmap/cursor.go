@@ -0,0 +1,116 @@
+package sortedmap
+
+// Cursor is a bidirectional position within a [SortedMap]. Unlike the
+// one-shot iterators, a cursor can move forward and backward repeatedly,
+// which suits interactive scrolling through sorted data.
+//
+// A cursor becomes invalid when it moves past either end of the map or when
+// the map is structurally modified (a key is inserted or deleted, or the map
+// is cleared or rebuilt). Updating the value of an existing key does not
+// invalidate it. Once invalid, a cursor stays invalid; create a new one with
+// [SortedMap.Cursor].
+type Cursor[K, V any] struct {
+	m       *SortedMap[K, V]
+	path    []*node[K, V] // ancestors from the root down to the current node
+	version int
+}
+
+// Cursor returns a cursor positioned at the smallest key greater than or
+// equal to at. If no such key exists the cursor is invalid.
+func (m *SortedMap[K, V]) Cursor(at K) *Cursor[K, V] {
+	// Descend as for ceiling, keeping the path up to the best candidate.
+	var path []*node[K, V]
+	best := 0
+	for n := m.root; n != nil; {
+		path = append(path, n)
+		switch c := m.cmp(at, n.key); {
+		case c < 0:
+			best = len(path)
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			best = len(path)
+			n = nil
+		}
+	}
+	return &Cursor[K, V]{m: m, path: path[:best], version: m.version}
+}
+
+// Valid reports whether the cursor is positioned at an entry.
+func (c *Cursor[K, V]) Valid() bool {
+	if len(c.path) > 0 && c.version != c.m.version {
+		c.path = nil
+	}
+	return len(c.path) > 0
+}
+
+// Key returns the key at the cursor, or the zero value if the cursor is
+// invalid.
+func (c *Cursor[K, V]) Key() K {
+	if !c.Valid() {
+		var zero K
+		return zero
+	}
+	return c.path[len(c.path)-1].key
+}
+
+// Value returns the value at the cursor, or the zero value if the cursor is
+// invalid.
+func (c *Cursor[K, V]) Value() V {
+	if !c.Valid() {
+		var zero V
+		return zero
+	}
+	return c.path[len(c.path)-1].value
+}
+
+// Next moves the cursor to the next larger key and reports whether it is
+// still valid.
+func (c *Cursor[K, V]) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+	n := c.path[len(c.path)-1]
+	if n.right != nil {
+		for n = n.right; n != nil; n = n.left {
+			c.path = append(c.path, n)
+		}
+		return true
+	}
+	// Climb until we leave a left subtree.
+	for len(c.path) > 1 {
+		child := c.path[len(c.path)-1]
+		c.path = c.path[:len(c.path)-1]
+		if c.path[len(c.path)-1].left == child {
+			return true
+		}
+	}
+	c.path = nil
+	return false
+}
+
+// Prev moves the cursor to the next smaller key and reports whether it is
+// still valid.
+func (c *Cursor[K, V]) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+	n := c.path[len(c.path)-1]
+	if n.left != nil {
+		for n = n.left; n != nil; n = n.right {
+			c.path = append(c.path, n)
+		}
+		return true
+	}
+	// Climb until we leave a right subtree.
+	for len(c.path) > 1 {
+		child := c.path[len(c.path)-1]
+		c.path = c.path[:len(c.path)-1]
+		if c.path[len(c.path)-1].right == child {
+			return true
+		}
+	}
+	c.path = nil
+	return false
+}
@@ -0,0 +1,86 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorForward(t *testing.T) {
+	m := New[int, int]()
+	for i := range 50 {
+		m.Put(i*2, i)
+	}
+	c := m.Cursor(7)
+	var keys []int
+	for ; c.Valid(); c.Next() {
+		require.Equal(t, c.Key()/2, c.Value())
+		keys = append(keys, c.Key())
+	}
+	require.Len(t, keys, 46)
+	assert.Equal(t, 8, keys[0])
+	assert.Equal(t, 98, keys[len(keys)-1])
+	assert.False(t, c.Next(), "Next past the end should stay invalid")
+}
+
+func TestCursorBackward(t *testing.T) {
+	m := New[int, int]()
+	for i := range 50 {
+		m.Put(i, i)
+	}
+	c := m.Cursor(20)
+	var keys []int
+	for ; c.Valid(); c.Prev() {
+		keys = append(keys, c.Key())
+	}
+	require.Len(t, keys, 21)
+	assert.Equal(t, 20, keys[0])
+	assert.Equal(t, 0, keys[len(keys)-1])
+}
+
+func TestCursorBothDirections(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		m.Put(k, "")
+	}
+	c := m.Cursor(25)
+	require.True(t, c.Valid())
+	assert.Equal(t, 30, c.Key())
+	assert.True(t, c.Next())
+	assert.Equal(t, 40, c.Key())
+	assert.True(t, c.Prev())
+	assert.True(t, c.Prev())
+	assert.Equal(t, 20, c.Key())
+	assert.True(t, c.Prev())
+	assert.Equal(t, 10, c.Key())
+	assert.False(t, c.Prev(), "Prev before the start should invalidate the cursor")
+	assert.False(t, c.Next(), "an invalid cursor should stay invalid")
+	assert.Zero(t, c.Key())
+}
+
+func TestCursorPastEnd(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 1)
+	assert.False(t, m.Cursor(2).Valid(), "cursor past the largest key should be invalid")
+	assert.False(t, New[int, int]().Cursor(0).Valid(), "cursor on empty map should be invalid")
+	assert.True(t, m.Cursor(1).Valid(), "cursor at an exact key should be valid")
+}
+
+func TestCursorInvalidatedByMutation(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 1)
+	m.Put(2, 2)
+
+	c := m.Cursor(1)
+	m.Put(1, 100) // value update is not structural
+	assert.True(t, c.Valid())
+	assert.Equal(t, 100, c.Value())
+
+	m.Put(3, 3)
+	assert.False(t, c.Valid(), "insert should invalidate the cursor")
+
+	c = m.Cursor(1)
+	m.Delete(2)
+	assert.False(t, c.Next(), "delete should invalidate the cursor")
+}
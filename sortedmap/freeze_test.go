@@ -0,0 +1,65 @@
+package sortedmap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	m := New[int, int]()
+	for i := range 5 {
+		m.Put(i, i)
+	}
+	m.Freeze()
+	assert.True(t, m.Frozen())
+
+	mutations := map[string]func(){
+		"Put":          func() { m.Put(9, 9) },
+		"Delete":       func() { m.Delete(1) },
+		"Clear":        func() { m.Clear() },
+		"PutBatch":     func() { m.PutBatch([]Entry[int, int]{{9, 9}}) },
+		"PutAllMap":    func() { PutAllMap(m, map[int]int{9: 9}) },
+		"DeleteRank":   func() { m.DeleteRank(0) },
+		"UpdateValues": func() { m.UpdateValues(func(_, v int) int { return v }) },
+		"Clamp":        func() { Clamp(m, 0, 1) },
+		"Compact":      func() { m.Compact(func(int) bool { return false }) },
+		"DrainRange":   func() { m.DrainRange(0, 1) },
+	}
+	for name, f := range mutations {
+		assert.Panics(t, f, "%s on a frozen map should panic", name)
+	}
+
+	assert.Equal(t, 5, m.Len(), "frozen map should be unchanged")
+	v, ok := m.Get(3)
+	assert.True(t, ok && v == 3)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(m.Keys()))
+	assert.Equal(t, []int{1, 2}, m.KeysInRange(1, 2))
+}
+
+func TestFreezeAppend(t *testing.T) {
+	m := New[string, []int]()
+	m.Freeze()
+	assert.Panics(t, func() { Append(m, "a", 1) })
+}
+
+func TestClone(t *testing.T) {
+	m := NewDescending[int, string]()
+	for i := range 50 {
+		m.Put(i, "v")
+	}
+	m.Freeze()
+
+	c := m.Clone()
+	assert.False(t, c.Frozen(), "clone should be mutable")
+	checkLLRB(t, c)
+	assert.Equal(t, slices.Collect(m.Keys()), slices.Collect(c.Keys()), "clone should keep order and comparator")
+
+	c.Put(100, "new")
+	c.Delete(0)
+	checkLLRB(t, c)
+	assert.Equal(t, 50, m.Len(), "original should be unaffected by changes to the clone")
+	assert.True(t, m.Contains(0))
+	assert.False(t, m.Contains(100))
+}
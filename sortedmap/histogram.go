@@ -0,0 +1,92 @@
+package sortedmap
+
+import "math"
+
+// Number is the set of key types that [Histogram] can split into
+// equal-width buckets. Unlike cmp.Ordered it excludes strings, whose keys
+// have no notion of width.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Histogram treats m as a frequency table and sums its counts into buckets
+// equal-width buckets spanning the numerically smallest to the largest
+// finite key. Bucket i covers [lo+i*w, lo+(i+1)*w), where w =
+// (hi-lo)/buckets, except that the last bucket also includes hi, so every
+// finite key falls in exactly one bucket. When all finite keys are equal
+// they all land in bucket 0.
+//
+// Floating-point keys that are not finite do not affect lo and hi: -Inf is
+// counted in the first bucket, +Inf in the last, and NaN keys are skipped.
+//
+// Buckets follow the keys' numeric values, not m's comparison function, so
+// maps created with [NewDescending] or a custom ordering are bucketed the
+// same as an ascending map with the same entries. Keys are restricted to
+// [Number] rather than cmp.Ordered because equal-width buckets need
+// arithmetic on keys, which strings do not support. Bucket positions are
+// computed in float64, so integer keys beyond 2^53 in magnitude are rounded
+// and may land in a neighbouring bucket near its edges.
+//
+// An empty map yields a zero-filled slice. Histogram panics if buckets is
+// less than 1.
+func Histogram[K Number](m *SortedMap[K, int], buckets int) []int {
+	if buckets < 1 {
+		panic("sortedmap: Histogram needs at least 1 bucket")
+	}
+	out := make([]int, buckets)
+	// m's comparator need not be ascending, so find the numeric extremes
+	// with a scan rather than Min and Max.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for k := range m.Keys() {
+		if f := float64(k); !math.IsInf(f, 0) && !math.IsNaN(f) {
+			lo, hi = min(lo, f), max(hi, f)
+		}
+	}
+	span := hi - lo
+	for k, count := range m.All() {
+		f := float64(k)
+		var i int
+		switch {
+		case math.IsNaN(f):
+			continue
+		case math.IsInf(f, -1):
+			i = 0
+		case math.IsInf(f, 1):
+			i = buckets - 1
+		case span > 0:
+			i = min(max(int((f-lo)/span*float64(buckets)), 0), buckets-1)
+		}
+		out[i] += count
+	}
+	return out
+}
+
+// BucketCounts counts the keys of m falling between consecutive boundaries,
+// which must be strictly ascending under m's ordering. The result has
+// len(boundaries)+1 elements: element 0 counts keys below boundaries[0],
+// element i counts keys in [boundaries[i-1], boundaries[i]), and the last
+// counts keys at or above the final boundary. With no boundaries it holds
+// just m.Len().
+//
+// The tree does not track subtree sizes, so the counts come from a single
+// in-order walk in O(n + k) time for k boundaries. BucketCounts panics if
+// the boundaries are not strictly ascending.
+func (m *SortedMap[K, V]) BucketCounts(boundaries []K) []int {
+	for i := 1; i < len(boundaries); i++ {
+		if m.cmp(boundaries[i-1], boundaries[i]) >= 0 {
+			panic("sortedmap: BucketCounts boundaries are not strictly ascending")
+		}
+	}
+	out := make([]int, len(boundaries)+1)
+	b := 0
+	m.inOrder(m.root, func(k K, _ V) bool {
+		for b < len(boundaries) && m.cmp(k, boundaries[b]) >= 0 {
+			b++
+		}
+		out[b]++
+		return true
+	})
+	return out
+}
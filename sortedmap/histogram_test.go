@@ -0,0 +1,95 @@
+package sortedmap
+
+import (
+	"cmp"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	m := New[int, int]()
+	// Keys 0..10, bucket width 2.5 with 4 buckets.
+	for k := 0; k <= 10; k++ {
+		m.Put(k, 1)
+	}
+	m.Put(10, 5)
+
+	// [0,2.5) [2.5,5) [5,7.5) [7.5,10]
+	assert.Equal(t, []int{3, 2, 3, 7}, Histogram(m, 4))
+	assert.Equal(t, []int{15}, Histogram(m, 1))
+}
+
+func TestHistogramFloatKeys(t *testing.T) {
+	m := New[float64, int]()
+	m.Put(-1.0, 2)
+	m.Put(0.0, 3)
+	m.Put(0.99, 4)
+	m.Put(1.0, 1)
+	assert.Equal(t, []int{2, 8}, Histogram(m, 2))
+}
+
+func TestHistogramDescending(t *testing.T) {
+	m := NewDescending[int, int]()
+	for k := range 10 {
+		m.Put(k, 1)
+	}
+	assert.Equal(t, []int{2, 2, 2, 2, 2}, Histogram(m, 5), "expected buckets by numeric value, not map order")
+
+	byParity := NewWithCompare[int, int](func(a, b int) int {
+		if c := cmp.Compare(a%2, b%2); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	for k := range 10 {
+		byParity.Put(k, 1)
+	}
+	assert.Equal(t, []int{2, 2, 2, 2, 2}, Histogram(byParity, 5))
+}
+
+func TestHistogramNonFinite(t *testing.T) {
+	m := New[float64, int]()
+	m.Put(1, 1)
+	m.Put(math.Inf(1), 2)
+	assert.Equal(t, []int{1, 0, 2}, Histogram(m, 3), "expected +Inf in the last bucket")
+
+	m.Put(math.Inf(-1), 4)
+	m.Put(5, 1)
+	m.Put(9, 1)
+	m.Put(math.NaN(), 8)
+	assert.Equal(t, []int{5, 0, 1, 3}, Histogram(m, 4), "expected -Inf first, +Inf last, NaN skipped")
+
+	onlyInf := New[float64, int]()
+	onlyInf.Put(math.Inf(-1), 1)
+	onlyInf.Put(math.Inf(1), 2)
+	onlyInf.Put(math.NaN(), 3)
+	assert.Equal(t, []int{1, 2}, Histogram(onlyInf, 2))
+}
+
+func TestHistogramEdgeCases(t *testing.T) {
+	assert.Equal(t, []int{0, 0, 0}, Histogram(New[int, int](), 3), "empty map should give zero-filled buckets")
+
+	single := New[uint8, int]()
+	single.Put(7, 4)
+	assert.Equal(t, []int{4, 0}, Histogram(single, 2), "equal keys should land in bucket 0")
+
+	assert.Panics(t, func() { Histogram(single, 0) })
+}
+
+func TestBucketCounts(t *testing.T) {
+	// Latency samples in milliseconds.
+	m := New[int, struct{}]()
+	for _, ms := range []int{1, 3, 5, 9, 10, 11, 50, 99, 100, 250} {
+		m.Put(ms, struct{}{})
+	}
+	assert.Equal(t, []int{2, 2, 4, 1, 1}, m.BucketCounts([]int{5, 10, 100, 200}))
+	assert.Equal(t, []int{10}, m.BucketCounts(nil))
+	assert.Equal(t, []int{0, 10}, m.BucketCounts([]int{0}))
+	assert.Equal(t, []int{10, 0}, m.BucketCounts([]int{1000}))
+	assert.Equal(t, []int{0, 0, 0}, New[int, int]().BucketCounts([]int{1, 2}))
+
+	assert.Panics(t, func() { m.BucketCounts([]int{10, 10}) })
+	assert.Panics(t, func() { m.BucketCounts([]int{10, 5}) })
+}
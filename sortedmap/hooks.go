@@ -0,0 +1,38 @@
+package sortedmap
+
+// ChangeOp identifies the operation reported to an [SortedMap.OnChange] hook.
+type ChangeOp int
+
+const (
+	// ChangePut reports a call to Put.
+	ChangePut ChangeOp = iota
+	// ChangeDelete reports a call to Delete.
+	ChangeDelete
+)
+
+// String returns "put" or "delete".
+func (op ChangeOp) String() string {
+	switch op {
+	case ChangePut:
+		return "put"
+	case ChangeDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// OnChange registers hook to be called after every Put and Delete, including
+// those made on the map's behalf by methods such as PutBatch, PutAllMap,
+// MergeSeq, Append, DeleteAll, DeleteRank, TrimFront, TrimBack, Compact, and
+// DrainRange. The hook receives the operation, the key, the value before and
+// after the call, and whether the key existed beforehand. For a Delete, new
+// is the zero value; for a Delete of a missing key or a Put of a new key,
+// old is the zero value and existed is false.
+//
+// Methods that rewrite values or the tree wholesale without Put or Delete,
+// such as UpdateValues, Clamp, and Clear, do not call the hook. A later call
+// to OnChange replaces the hook, and nil removes it. Maps without a hook pay
+// only a nil check per Put and Delete.
+func (m *SortedMap[K, V]) OnChange(hook func(op ChangeOp, key K, old, new V, existed bool)) {
+	m.onChange = hook
+}
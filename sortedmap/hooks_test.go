@@ -0,0 +1,92 @@
+package sortedmap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnChange(t *testing.T) {
+	m := New[string, int]()
+	var log []string
+	m.OnChange(func(op ChangeOp, key string, old, new int, existed bool) {
+		log = append(log, fmt.Sprintf("%v %s %d->%d %v", op, key, old, new, existed))
+	})
+
+	m.Put("a", 1)
+	m.Put("a", 2)
+	m.Delete("a")
+	m.Delete("missing")
+
+	assert.Equal(t, []string{
+		"put a 0->1 false",
+		"put a 1->2 true",
+		"delete a 2->0 true",
+		"delete missing 0->0 false",
+	}, log)
+}
+
+func TestOnChangeSeesState(t *testing.T) {
+	m := New[int, int]()
+	m.OnChange(func(op ChangeOp, key int, _, _ int, _ bool) {
+		assert.Equal(t, op == ChangePut, m.Contains(key), "hook should run after the change is applied")
+	})
+	m.Put(1, 1)
+	m.Delete(1)
+}
+
+func TestOnChangeBulkOperations(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 1)
+	puts, deletes := 0, 0
+	m.OnChange(func(op ChangeOp, _ int, _, _ int, _ bool) {
+		if op == ChangePut {
+			puts++
+		} else {
+			deletes++
+		}
+	})
+
+	// Large enough to take the rebuild path without a hook.
+	m.PutBatch([]Entry[int, int]{{2, 2}, {3, 3}, {4, 4}})
+	assert.Equal(t, 3, puts, "PutBatch should report each entry while hooked")
+	PutAllMap(m, map[int]int{5: 5, 6: 6})
+	assert.Equal(t, 5, puts)
+
+	for range m.DrainRange(1, 2) {
+	}
+	m.DeleteRank(0)
+	assert.Equal(t, 3, deletes)
+	checkLLRB(t, m)
+
+	m.OnChange(nil)
+	m.Put(100, 100)
+	assert.Equal(t, 5, puts, "removed hook should not fire")
+}
+
+func TestOnChangeAppendAndCompact(t *testing.T) {
+	m := New[string, []int]()
+	var log []string
+	m.OnChange(func(op ChangeOp, key string, old, new []int, existed bool) {
+		log = append(log, fmt.Sprintf("%v %s %v->%v %v", op, key, old, new, existed))
+	})
+
+	Append(m, "a", 1)
+	Append(m, "a", 2, 3)
+	Append(m, "b")
+	assert.Equal(t, 1, m.Compact(func(v []int) bool { return len(v) == 0 }))
+	assert.Equal(t, []string{
+		"put a []->[1] false",
+		"put a [1]->[1 2 3] true",
+		"put b []->[] false",
+		"delete b []->[] true",
+	}, log)
+	checkLLRB(t, m)
+}
+
+func TestChangeOpString(t *testing.T) {
+	assert.Equal(t, "put", ChangePut.String())
+	assert.Equal(t, "delete", ChangeDelete.String())
+	assert.Equal(t, "unknown", ChangeOp(99).String())
+}
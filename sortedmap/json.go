@@ -0,0 +1,112 @@
+package sortedmap
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// EncodeJSON writes the map to w as a JSON array of [key, value] pairs in
+// ascending key order, encoding one entry at a time so that the whole
+// serialized form is never held in memory. Pairs are used rather than a JSON
+// object so that keys of any type round-trip.
+func (m *SortedMap[K, V]) EncodeJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteByte('[')
+	var err error
+	first := true
+	m.inOrder(m.root, func(k K, v V) bool {
+		var kb, vb []byte
+		if kb, err = json.Marshal(k); err != nil {
+			return false
+		}
+		if vb, err = json.Marshal(v); err != nil {
+			return false
+		}
+		if !first {
+			bw.WriteByte(',')
+		}
+		first = false
+		bw.WriteByte('[')
+		bw.Write(kb)
+		bw.WriteByte(',')
+		bw.Write(vb)
+		bw.WriteByte(']')
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteByte(']')
+	return bw.Flush()
+}
+
+// DecodeJSON reads a map written by [SortedMap.EncodeJSON] from r, ordering
+// keys by their natural ordering. Input that is already in ascending key
+// order, as EncodeJSON produces, is bulk-loaded in O(n); otherwise later
+// pairs overwrite earlier ones with the same key. A JSON null yields an
+// empty map.
+func DecodeJSON[K cmp.Ordered, V any](r io.Reader) (*SortedMap[K, V], error) {
+	return DecodeJSONWithCompare[K, V](r, cmp.Compare[K])
+}
+
+// DecodeJSONWithCompare is like [DecodeJSON] but orders keys using compare.
+func DecodeJSONWithCompare[K, V any](r io.Reader, compare func(a, b K) int) (*SortedMap[K, V], error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	m := NewWithCompare[K, V](compare)
+	if tok == nil {
+		return m, nil
+	}
+	if tok != json.Delim('[') {
+		return nil, errors.New("sortedmap: expected JSON array of [key, value] pairs")
+	}
+	var entries []Entry[K, V]
+	ascending := true
+	for dec.More() {
+		e, err := decodePair[K, V](dec)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(entries); n > 0 && compare(entries[n-1].Key, e.Key) >= 0 {
+			ascending = false
+		}
+		entries = append(entries, e)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if ascending {
+		m.load(entries)
+	} else {
+		m.PutBatch(entries)
+	}
+	return m, nil
+}
+
+// decodePair reads a single [key, value] array from dec.
+func decodePair[K, V any](dec *json.Decoder) (Entry[K, V], error) {
+	var e Entry[K, V]
+	if tok, err := dec.Token(); err != nil {
+		return e, err
+	} else if tok != json.Delim('[') {
+		return e, errors.New("sortedmap: expected [key, value] pair")
+	}
+	if err := dec.Decode(&e.Key); err != nil {
+		return e, err
+	}
+	if err := dec.Decode(&e.Value); err != nil {
+		return e, err
+	}
+	if tok, err := dec.Token(); err != nil {
+		return e, err
+	} else if tok != json.Delim(']') {
+		return e, errors.New("sortedmap: expected end of [key, value] pair")
+	}
+	return e, nil
+}
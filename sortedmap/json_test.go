@@ -0,0 +1,83 @@
+package sortedmap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	m := New[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	m.Put("c", 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.EncodeJSON(&buf))
+	assert.Equal(t, `[["a",1],["b",2],["c",3]]`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, New[int, int]().EncodeJSON(&buf))
+	assert.Equal(t, `[]`, buf.String())
+}
+
+func TestEncodeJSON_UnsupportedValue(t *testing.T) {
+	m := New[int, func()]()
+	m.Put(1, func() {})
+	var buf bytes.Buffer
+	assert.Error(t, m.EncodeJSON(&buf))
+}
+
+func TestDecodeJSON_RoundTrip(t *testing.T) {
+	original := New[int, string]()
+	for i := range 1000 {
+		original.Put(i*3, strings.Repeat("x", i%5))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, original.EncodeJSON(&buf))
+
+	restored, err := DecodeJSON[int, string](&buf)
+	require.NoError(t, err)
+	checkLLRB(t, restored)
+	require.Equal(t, original.Len(), restored.Len())
+	for k, v := range original.All() {
+		got, ok := restored.Get(k)
+		require.True(t, ok && got == v, "Get(%d) = (%q, %v), want %q", k, got, ok, v)
+	}
+}
+
+func TestDecodeJSON_Unsorted(t *testing.T) {
+	m, err := DecodeJSON[int, string](strings.NewReader(`[[3,"c"],[1,"a"],[3,"C"],[2,"b"]]`))
+	require.NoError(t, err)
+	checkLLRB(t, m)
+	assert.Equal(t, "{1: a, 2: b, 3: C}", m.String())
+}
+
+func TestDecodeJSON_NullAndEmpty(t *testing.T) {
+	m, err := DecodeJSON[int, int](strings.NewReader(`null`))
+	require.NoError(t, err)
+	assert.True(t, m.IsEmpty())
+	m.Put(1, 1)
+	assert.Equal(t, 1, m.Len(), "decoded map should be usable")
+
+	m, err = DecodeJSON[int, int](strings.NewReader(`[]`))
+	require.NoError(t, err)
+	assert.True(t, m.IsEmpty())
+}
+
+func TestDecodeJSONWithCompare(t *testing.T) {
+	m, err := DecodeJSONWithCompare[int, int](strings.NewReader(`[[3,0],[2,0],[1,0]]`), Reverse(func(a, b int) int { return a - b }))
+	require.NoError(t, err)
+	k, _, _ := m.Min()
+	assert.Equal(t, 3, k)
+}
+
+func TestDecodeJSON_Invalid(t *testing.T) {
+	for _, in := range []string{``, `{}`, `[1]`, `[[1]]`, `[[1,2,3]]`, `[["a",1]]`, `[[1,2]`} {
+		_, err := DecodeJSON[int, int](strings.NewReader(in))
+		assert.Error(t, err, "DecodeJSON(%q)", in)
+	}
+}
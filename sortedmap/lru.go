@@ -0,0 +1,136 @@
+package sortedmap
+
+import (
+	"cmp"
+	"container/list"
+	"iter"
+)
+
+// LRU is a capacity-bounded [SortedMap] that evicts the least-recently-used
+// entry when a Put would exceed its capacity. Keys remain sorted, so range
+// queries work as on a plain SortedMap; a separate access list tracks recency.
+//
+// Get, Put, and Touch count as accesses. Contains and iteration do not.
+//
+// The zero value is not usable; create instances with [NewLRU] or
+// [NewLRUWithCompare].
+type LRU[K, V any] struct {
+	tree     *SortedMap[K, *list.Element]
+	order    *list.List // front is most recently used; elements hold *Entry[K, V]
+	capacity int
+}
+
+// NewLRU creates an empty LRU holding at most capacity entries, ordering keys
+// by their natural ordering. It panics if capacity is less than 1.
+func NewLRU[K cmp.Ordered, V any](capacity int) *LRU[K, V] {
+	return NewLRUWithCompare[K, V](capacity, cmp.Compare[K])
+}
+
+// NewLRUWithCompare creates an empty LRU holding at most capacity entries,
+// ordering keys with compare. It panics if capacity is less than 1.
+func NewLRUWithCompare[K, V any](capacity int, compare func(a, b K) int) *LRU[K, V] {
+	if capacity < 1 {
+		panic("sortedmap: LRU capacity must be at least 1")
+	}
+	return &LRU[K, V]{
+		tree:     NewWithCompare[K, *list.Element](compare),
+		order:    list.New(),
+		capacity: capacity,
+	}
+}
+
+// Put inserts or updates the value associated with key and marks it as most
+// recently used. If the insert grows the LRU past its capacity, the least
+// recently used entry is evicted.
+func (l *LRU[K, V]) Put(key K, value V) {
+	if el, ok := l.tree.Get(key); ok {
+		el.Value.(*Entry[K, V]).Value = value
+		l.order.MoveToFront(el)
+		return
+	}
+	l.tree.Put(key, l.order.PushFront(&Entry[K, V]{Key: key, Value: value}))
+	if l.tree.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		l.tree.Delete(oldest.Value.(*Entry[K, V]).Key)
+	}
+}
+
+// Get returns the value associated with key and true, marking the key as
+// most recently used. It returns the zero value and false if the key is not
+// present.
+func (l *LRU[K, V]) Get(key K) (V, bool) {
+	el, ok := l.tree.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	l.order.MoveToFront(el)
+	return el.Value.(*Entry[K, V]).Value, true
+}
+
+// Touch marks key as most recently used without reading its value. It
+// reports whether the key was present.
+func (l *LRU[K, V]) Touch(key K) bool {
+	el, ok := l.tree.Get(key)
+	if ok {
+		l.order.MoveToFront(el)
+	}
+	return ok
+}
+
+// Delete removes the key and its value. It reports whether the key was present.
+func (l *LRU[K, V]) Delete(key K) bool {
+	el, ok := l.tree.Get(key)
+	if !ok {
+		return false
+	}
+	l.order.Remove(el)
+	l.tree.Delete(key)
+	return true
+}
+
+// Contains reports whether key is present without counting as an access.
+func (l *LRU[K, V]) Contains(key K) bool { return l.tree.Contains(key) }
+
+// Len returns the number of entries currently held.
+func (l *LRU[K, V]) Len() int { return l.tree.Len() }
+
+// Cap returns the maximum number of entries the LRU holds before evicting.
+func (l *LRU[K, V]) Cap() int { return l.capacity }
+
+// Oldest returns the least recently used key and its value, which is the
+// entry the next overflowing Put would evict. It does not count as an access.
+func (l *LRU[K, V]) Oldest() (K, V, bool) {
+	el := l.order.Back()
+	if el == nil {
+		var zk K
+		var zv V
+		return zk, zv, false
+	}
+	e := el.Value.(*Entry[K, V])
+	return e.Key, e.Value, true
+}
+
+// All returns an iterator over all key-value pairs in ascending key order.
+func (l *LRU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, el := range l.tree.All() {
+			if !yield(k, el.Value.(*Entry[K, V]).Value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over key-value pairs whose keys lie in [from, to]
+// (inclusive) in ascending order.
+func (l *LRU[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, el := range l.tree.Range(from, to) {
+			if !yield(k, el.Value.(*Entry[K, V]).Value) {
+				return
+			}
+		}
+	}
+}
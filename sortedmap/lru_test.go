@@ -0,0 +1,111 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lruKeys[K, V any](l *LRU[K, V]) []K {
+	var keys []K
+	for k := range l.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	l := NewLRU[int, string](3)
+	l.Put(3, "three")
+	l.Put(1, "one")
+	l.Put(2, "two")
+	l.Put(4, "four") // evicts 3
+
+	require.Equal(t, 3, l.Len())
+	assert.False(t, l.Contains(3), "expected 3 to be evicted")
+	assert.Equal(t, []int{1, 2, 4}, lruKeys(l))
+}
+
+func TestLRUGetCountsAsAccess(t *testing.T) {
+	l := NewLRU[int, string](2)
+	l.Put(1, "one")
+	l.Put(2, "two")
+
+	v, ok := l.Get(1)
+	require.True(t, ok)
+	assert.Equal(t, "one", v)
+
+	l.Put(3, "three") // evicts 2, not 1
+	assert.True(t, l.Contains(1), "expected 1 to survive after Get")
+	assert.False(t, l.Contains(2), "expected 2 to be evicted")
+}
+
+func TestLRUTouch(t *testing.T) {
+	l := NewLRU[int, int](2)
+	l.Put(1, 10)
+	l.Put(2, 20)
+	assert.True(t, l.Touch(1), "Touch(1) should return true")
+	assert.False(t, l.Touch(99), "Touch(99) should return false for missing key")
+
+	k, v, ok := l.Oldest()
+	assert.True(t, ok)
+	assert.Equal(t, 2, k)
+	assert.Equal(t, 20, v)
+
+	l.Put(3, 30)
+	assert.Equal(t, []int{1, 3}, lruKeys(l))
+}
+
+func TestLRUPutUpdateRefreshes(t *testing.T) {
+	l := NewLRU[string, int](2)
+	l.Put("a", 1)
+	l.Put("b", 2)
+	l.Put("a", 100)
+	l.Put("c", 3) // evicts b
+
+	require.Equal(t, 2, l.Len())
+	v, ok := l.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 100, v)
+	assert.False(t, l.Contains("b"), "expected b to be evicted")
+}
+
+func TestLRUContainsIsNotAccess(t *testing.T) {
+	l := NewLRU[int, int](2)
+	l.Put(1, 1)
+	l.Put(2, 2)
+	assert.True(t, l.Contains(1))
+	l.Put(3, 3)
+	assert.False(t, l.Contains(1), "Contains should not refresh recency")
+}
+
+func TestLRUDelete(t *testing.T) {
+	l := NewLRU[int, int](2)
+	l.Put(1, 1)
+	l.Put(2, 2)
+	assert.True(t, l.Delete(1))
+	assert.False(t, l.Delete(1))
+	l.Put(3, 3)
+	assert.Equal(t, []int{2, 3}, lruKeys(l))
+
+	_, _, ok := NewLRU[int, int](1).Oldest()
+	assert.False(t, ok, "Oldest on empty LRU should return false")
+}
+
+func TestLRURange(t *testing.T) {
+	l := NewLRU[int, int](10)
+	for i := 1; i <= 10; i++ {
+		l.Put(i, i*10)
+	}
+	var vals []int
+	for _, v := range l.Range(3, 5) {
+		vals = append(vals, v)
+	}
+	assert.Equal(t, []int{30, 40, 50}, vals)
+	assert.Equal(t, 10, l.Cap())
+}
+
+func TestLRUInvalidCapacity(t *testing.T) {
+	assert.Panics(t, func() { NewLRU[int, int](0) })
+}
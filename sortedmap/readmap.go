@@ -0,0 +1,28 @@
+package sortedmap
+
+import "iter"
+
+// ReadMap is the read-only subset of [SortedMap]'s methods. Functions that
+// only query a map can accept a ReadMap to document that, and tests can
+// substitute their own implementation.
+type ReadMap[K, V any] interface {
+	Get(key K) (V, bool)
+	Contains(key K) bool
+	Len() int
+	All() iter.Seq2[K, V]
+	Range(from, to K) iter.Seq2[K, V]
+	Floor(key K) (K, V, bool)
+	Ceiling(key K) (K, V, bool)
+	Min() (K, V, bool)
+	Max() (K, V, bool)
+}
+
+var _ ReadMap[int, int] = (*SortedMap[int, int])(nil)
+
+// Read returns m as a [ReadMap]. It is the same map, not a copy or a
+// guarding wrapper: later changes to m are visible through the result, and
+// a type assertion recovers the *SortedMap. Use [SortedMap.Freeze] when the
+// map itself must not change.
+func (m *SortedMap[K, V]) Read() ReadMap[K, V] {
+	return m
+}
@@ -0,0 +1,39 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sumRange only reads, so it accepts a ReadMap.
+func sumRange(r ReadMap[int, int], from, to int) int {
+	total := 0
+	for _, v := range r.Range(from, to) {
+		total += v
+	}
+	return total
+}
+
+func TestRead(t *testing.T) {
+	m := New[int, int]()
+	for i := 1; i <= 5; i++ {
+		m.Put(i, i*10)
+	}
+	r := m.Read()
+	assert.Equal(t, 90, sumRange(r, 2, 4))
+	assert.Equal(t, 5, r.Len())
+
+	k, v, ok := r.Floor(10)
+	assert.True(t, ok && k == 5 && v == 50)
+	k, _, ok = r.Min()
+	assert.True(t, ok && k == 1)
+
+	m.Put(6, 60)
+	assert.True(t, r.Contains(6), "expected Read to be a view, not a copy")
+	var keys []int
+	for k := range r.All() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, keys)
+}
@@ -8,6 +8,7 @@ import (
 	"cmp"
 	"fmt"
 	"iter"
+	"slices"
 	"strings"
 )
 
@@ -30,15 +31,26 @@ func isRed[K, V any](n *node[K, V]) bool {
 	return n != nil && n.color == red
 }
 
+// Entry is a single key-value pair stored in a [SortedMap].
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
 // SortedMap is an ordered key-value map that maintains keys in sorted order
 // using a left-leaning red-black tree. It provides O(log n) time for Put,
 // Get, Delete, Min, Max, Floor, and Ceiling.
 //
 // The zero value is not usable; create instances with [New] or [NewWithCompare].
 type SortedMap[K, V any] struct {
-	root *node[K, V]
-	size int
-	cmp  func(a, b K) int
+	root    *node[K, V]
+	size    int
+	cmp     func(a, b K) int
+	version int // incremented on every structural change; see Cursor
+
+	// onChange is the hook registered with OnChange, if any.
+	onChange func(op ChangeOp, key K, old, new V, existed bool)
+	frozen   bool // see Freeze
 }
 
 // New creates an empty SortedMap that orders keys using their natural ordering.
@@ -53,14 +65,238 @@ func NewWithCompare[K, V any](compare func(a, b K) int) *SortedMap[K, V] {
 	return &SortedMap[K, V]{cmp: compare}
 }
 
+// NewWithCompareChecked is like [NewWithCompare] but verifies compare on
+// every comparison the map makes, panicking as soon as it sees a pair of
+// keys for which compare(a, b) and compare(b, a) do not have opposite signs
+// (or are not both zero), or a key that does not compare equal to itself.
+// A comparator that breaks these rules would otherwise corrupt the tree
+// silently. The checks triple the number of comparator calls, so use this
+// constructor in tests and development and NewWithCompare in production.
+func NewWithCompareChecked[K, V any](compare func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{cmp: checkedCompare(compare)}
+}
+
+func checkedCompare[K any](compare func(a, b K) int) func(a, b K) int {
+	return func(a, b K) int {
+		ab, ba := compare(a, b), compare(b, a)
+		if cmp.Compare(ab, 0) != -cmp.Compare(ba, 0) {
+			panic(fmt.Sprintf("sortedmap: inconsistent comparator: compare(%v, %v) = %d but compare(%v, %v) = %d", a, b, ab, b, a, ba))
+		}
+		if aa := compare(a, a); aa != 0 {
+			panic(fmt.Sprintf("sortedmap: inconsistent comparator: compare(%v, %v) = %d, want 0", a, a, aa))
+		}
+		return ab
+	}
+}
+
+// NewDescending creates an empty SortedMap that orders keys in reverse
+// natural order, so Min returns the largest key and iteration runs from
+// largest to smallest.
+func NewDescending[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{cmp: Reverse(cmp.Compare[K])}
+}
+
+// Reverse returns a comparison function that orders keys in the opposite
+// order to compare.
+func Reverse[K any](compare func(a, b K) int) func(a, b K) int {
+	return func(a, b K) int { return compare(b, a) }
+}
+
+// FromSorted creates a SortedMap from entries whose keys are in strictly
+// ascending natural order. The tree is built directly in O(n) time rather
+// than by n individual Puts. It panics if the keys are not strictly
+// ascending.
+func FromSorted[K cmp.Ordered, V any](entries []Entry[K, V]) *SortedMap[K, V] {
+	return FromSortedWithCompare(entries, cmp.Compare[K])
+}
+
+// FromSortedWithCompare is like [FromSorted] but orders keys using compare.
+func FromSortedWithCompare[K, V any](entries []Entry[K, V], compare func(a, b K) int) *SortedMap[K, V] {
+	for i := 1; i < len(entries); i++ {
+		if compare(entries[i-1].Key, entries[i].Key) >= 0 {
+			panic("sortedmap: FromSorted entries are not strictly ascending")
+		}
+	}
+	m := &SortedMap[K, V]{cmp: compare}
+	m.load(entries)
+	return m
+}
+
 // ---------- basic operations ----------
 
 // Put inserts or updates the value associated with key.
 func (m *SortedMap[K, V]) Put(key K, value V) {
+	m.mustBeMutable()
+	if m.onChange != nil {
+		old, existed := m.Get(key)
+		m.root = m.put(m.root, key, value)
+		m.root.color = black
+		m.onChange(ChangePut, key, old, value, existed)
+		return
+	}
 	m.root = m.put(m.root, key, value)
 	m.root.color = black
 }
 
+// Append appends values to the slice stored under key, creating the entry
+// if it does not exist, in a single descent of the tree. It is the multimap
+// idiom for maps that group values by key. While an [SortedMap.OnChange]
+// hook is set, Append instead reads the slice and stores the result with a
+// Put, so the hook sees the change.
+func Append[K, V any](m *SortedMap[K, []V], key K, values ...V) {
+	m.mustBeMutable()
+	if m.onChange != nil {
+		old, _ := m.Get(key)
+		m.Put(key, append(old, values...))
+		return
+	}
+	m.root = m.upsert(m.root, key, func(old []V, _ bool) []V {
+		return append(old, values...)
+	})
+	m.root.color = black
+}
+
+// MergeSeq inserts every key-value pair yielded by seq. When a key is
+// already present, resolve is called with the existing and incoming values
+// and its result is stored; new keys take the incoming value as is. seq may
+// yield keys in any order, and a key yielded more than once is resolved
+// against the value stored by its earlier occurrence. Each pair costs one
+// descent of the tree and, if an [SortedMap.OnChange] hook is set, fires it
+// as a put.
+//
+// seq must not iterate m itself, since m is modified during the merge; to
+// merge a window of a map into itself, collect the window first.
+func (m *SortedMap[K, V]) MergeSeq(seq iter.Seq2[K, V], resolve func(k K, old, new V) V) {
+	m.mustBeMutable()
+	for k, v := range seq {
+		var old V
+		var existed bool
+		m.root = m.upsert(m.root, k, func(cur V, exists bool) V {
+			if !exists {
+				return v
+			}
+			old, existed = cur, true
+			v = resolve(k, cur, v)
+			return v
+		})
+		m.root.color = black
+		if m.onChange != nil {
+			m.onChange(ChangePut, k, old, v, existed)
+		}
+	}
+}
+
+// batchRebuildRatio is the crossover used by [SortedMap.PutBatch]: a batch of
+// at least Len()/batchRebuildRatio entries rebuilds the tree in one pass
+// instead of inserting entries one at a time.
+const batchRebuildRatio = 4
+
+// PutBatch inserts or updates every entry in entries. If a key appears more
+// than once in the batch, the last occurrence wins. entries is not modified.
+//
+// Small batches are inserted with individual Puts, as is every batch while
+// an [SortedMap.OnChange] hook is set. Once the batch holds at least a
+// quarter as many entries as the map, PutBatch instead sorts it, merges it
+// with the existing entries, and rebuilds the tree, which costs
+// O(n + m log m) rather than O(m log(n+m)) with rebalancing on every insert.
+func (m *SortedMap[K, V]) PutBatch(entries []Entry[K, V]) {
+	m.mustBeMutable()
+	if len(entries) == 0 {
+		return
+	}
+	if len(entries)*batchRebuildRatio < m.size || m.onChange != nil {
+		for _, e := range entries {
+			m.Put(e.Key, e.Value)
+		}
+		return
+	}
+
+	// Sort by key, breaking ties by position so that the last occurrence of
+	// a duplicate key sorts last; this is cheaper than a stable sort.
+	type indexed struct {
+		Entry[K, V]
+		i int
+	}
+	sorted := make([]indexed, len(entries))
+	for i, e := range entries {
+		sorted[i] = indexed{e, i}
+	}
+	slices.SortFunc(sorted, func(a, b indexed) int {
+		if c := m.cmp(a.Key, b.Key); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.i, b.i)
+	})
+	batch := make([]Entry[K, V], 0, len(sorted))
+	for _, e := range sorted {
+		if n := len(batch); n > 0 && m.cmp(batch[n-1].Key, e.Key) == 0 {
+			batch[n-1] = e.Entry
+			continue
+		}
+		batch = append(batch, e.Entry)
+	}
+
+	// Merge into the existing nodes, reusing them so that only keys new to
+	// the map allocate.
+	merged := make([]*node[K, V], 0, m.size+len(batch))
+	i := 0
+	m.inOrderNodes(m.root, func(n *node[K, V]) {
+		for i < len(batch) && m.cmp(batch[i].Key, n.key) < 0 {
+			merged = append(merged, &node[K, V]{key: batch[i].Key, value: batch[i].Value})
+			i++
+		}
+		if i < len(batch) && m.cmp(batch[i].Key, n.key) == 0 {
+			n.value = batch[i].Value
+			i++
+		}
+		merged = append(merged, n)
+	})
+	for ; i < len(batch); i++ {
+		merged = append(merged, &node[K, V]{key: batch[i].Key, value: batch[i].Value})
+	}
+	m.relink(merged)
+}
+
+// PutKeys inserts or updates every key in keys with the same value, such as
+// when initializing a batch of flags to a default. It follows the strategy
+// of [SortedMap.PutBatch]: small batches use individual Puts, and a batch
+// holding at least a quarter as many keys as the map is sorted, merged with
+// the existing entries, and rebuilt into a balanced tree in one pass.
+// Duplicate keys are stored once. keys is not modified.
+func (m *SortedMap[K, V]) PutKeys(value V, keys ...K) {
+	m.mustBeMutable()
+	if len(keys)*batchRebuildRatio < m.size || m.onChange != nil {
+		for _, k := range keys {
+			m.Put(k, value)
+		}
+		return
+	}
+	entries := make([]Entry[K, V], len(keys))
+	for i, k := range keys {
+		entries[i] = Entry[K, V]{Key: k, Value: value}
+	}
+	m.PutBatch(entries)
+}
+
+// PutAllMap inserts or updates every entry of src in m. It is a
+// package-level function because a map[K]V requires a comparable K, which
+// SortedMap does not. Like [SortedMap.PutBatch], a src large relative to m
+// is merged by rebuilding the tree rather than by individual Puts.
+func PutAllMap[K comparable, V any](m *SortedMap[K, V], src map[K]V) {
+	m.mustBeMutable()
+	if len(src)*batchRebuildRatio < m.size {
+		for k, v := range src {
+			m.Put(k, v)
+		}
+		return
+	}
+	entries := make([]Entry[K, V], 0, len(src))
+	for k, v := range src {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+	}
+	m.PutBatch(entries)
+}
+
 // Get returns the value associated with key and true, or the zero value and
 // false if the key is not present.
 func (m *SortedMap[K, V]) Get(key K) (V, bool) {
@@ -79,21 +315,218 @@ func (m *SortedMap[K, V]) Get(key K) (V, bool) {
 	return zero, false
 }
 
+// GetAny returns the first of keys present in the map along with its value,
+// for lookups with precedence such as a specific key falling back to a
+// default one. It descends the tree once per candidate and stops at the
+// first hit. If none is present it returns zero values and false.
+func (m *SortedMap[K, V]) GetAny(keys ...K) (K, V, bool) {
+	for _, k := range keys {
+		if v, ok := m.Get(k); ok {
+			return k, v, true
+		}
+	}
+	var zk K
+	var zv V
+	return zk, zv, false
+}
+
 // Delete removes the key and its value from the map. It reports whether the
 // key was present.
 func (m *SortedMap[K, V]) Delete(key K) bool {
-	if !m.Contains(key) {
-		return false
+	m.mustBeMutable()
+	old, ok := m.Get(key)
+	if ok {
+		m.remove(key)
+	}
+	if m.onChange != nil {
+		var zero V
+		m.onChange(ChangeDelete, key, old, zero, ok)
 	}
+	return ok
+}
+
+// remove deletes key, which must be present in the map.
+func (m *SortedMap[K, V]) remove(key K) {
 	if !isRed(m.root.left) && !isRed(m.root.right) {
 		m.root.color = red
 	}
 	m.root = m.del(m.root, key)
 	m.size--
+	m.version++
 	if m.root != nil {
 		m.root.color = black
 	}
-	return true
+}
+
+// DeleteAll removes every key in keys that is present in the map and
+// returns the number of entries actually deleted. Duplicate and absent keys
+// are ignored. keys is not modified.
+//
+// Like [SortedMap.PutBatch], small batches and every batch while an
+// [SortedMap.OnChange] hook is set use individual Deletes. A batch holding
+// at least a quarter as many keys as the map is instead sorted and merged
+// against the existing entries, and the survivors are relinked into a fresh
+// balanced tree, avoiding the rotations of many separate deletes.
+func (m *SortedMap[K, V]) DeleteAll(keys ...K) int {
+	m.mustBeMutable()
+	if len(keys) == 0 || m.size == 0 {
+		return 0
+	}
+	if len(keys)*batchRebuildRatio < m.size || m.onChange != nil {
+		deleted := 0
+		for _, k := range keys {
+			if m.Delete(k) {
+				deleted++
+			}
+		}
+		return deleted
+	}
+
+	sorted := slices.Clone(keys)
+	slices.SortFunc(sorted, m.cmp)
+	kept := make([]*node[K, V], 0, m.size)
+	i := 0
+	m.inOrderNodes(m.root, func(n *node[K, V]) {
+		for i < len(sorted) && m.cmp(sorted[i], n.key) < 0 {
+			i++
+		}
+		if i < len(sorted) && m.cmp(sorted[i], n.key) == 0 {
+			return
+		}
+		kept = append(kept, n)
+	})
+	deleted := m.size - len(kept)
+	if deleted > 0 {
+		m.relink(kept)
+	}
+	return deleted
+}
+
+// KeyAt returns the i-th smallest key, counting from zero, or the zero key
+// and false if i is out of range. The tree does not track subtree sizes, so
+// this walks the keys in order from whichever end is nearer to i, taking
+// O(min(i, n-i)) time. Callers indexing repeatedly should collect
+// [SortedMap.KeysSlicePreSized] once instead.
+func (m *SortedMap[K, V]) KeyAt(i int) (K, bool) {
+	n := m.nodeAt(i)
+	if n == nil {
+		var zk K
+		return zk, false
+	}
+	return n.key, true
+}
+
+// DeleteRank removes the entry with the i-th smallest key (counting from
+// zero) and returns it. If i is out of range it returns zero values and
+// false. As with [SortedMap.KeyAt], locating the entry walks from the
+// nearer end in O(min(i, n-i)) time, before the O(log n) delete.
+func (m *SortedMap[K, V]) DeleteRank(i int) (K, V, bool) {
+	m.mustBeMutable()
+	n := m.nodeAt(i)
+	if n == nil {
+		var zk K
+		var zv V
+		return zk, zv, false
+	}
+	// Delete may overwrite n with its successor, so copy the entry first.
+	k, v := n.key, n.value
+	m.Delete(k)
+	return k, v, true
+}
+
+// TrimFront deletes the entries with the smallest keys until at most n
+// remain, keeping the n largest, and returns the number removed. It suits
+// bounded sliding windows, such as keeping the latest 1000 timestamps. Each
+// removal is an O(log n) delete of the minimum.
+func (m *SortedMap[K, V]) TrimFront(n int) int {
+	return m.trim(n, m.minNode, m.deleteMin)
+}
+
+// TrimBack deletes the entries with the largest keys until at most n
+// remain, keeping the n smallest, and returns the number removed. Each
+// removal is an O(log n) delete of the maximum.
+func (m *SortedMap[K, V]) TrimBack(n int) int {
+	return m.trim(n, m.maxNode, m.deleteMax)
+}
+
+// trim removes the node found by end with deleteEnd until at most n entries
+// remain, reporting each removal to the OnChange hook.
+func (m *SortedMap[K, V]) trim(n int, end func(*node[K, V]) *node[K, V], deleteEnd func(*node[K, V]) *node[K, V]) int {
+	m.mustBeMutable()
+	removed := 0
+	for m.size > max(n, 0) {
+		e := end(m.root)
+		k, v := e.key, e.value
+		if !isRed(m.root.left) && !isRed(m.root.right) {
+			m.root.color = red
+		}
+		m.root = deleteEnd(m.root)
+		m.size--
+		m.version++
+		if m.root != nil {
+			m.root.color = black
+		}
+		removed++
+		if m.onChange != nil {
+			var zero V
+			m.onChange(ChangeDelete, k, v, zero, true)
+		}
+	}
+	return removed
+}
+
+// UpdateValues replaces every value with f(key, value), visiting keys in
+// ascending order. Keys and tree structure are left untouched, so no
+// rebalancing takes place.
+func (m *SortedMap[K, V]) UpdateValues(f func(K, V) V) {
+	m.mustBeMutable()
+	m.inOrderNodes(m.root, func(n *node[K, V]) {
+		n.value = f(n.key, n.value)
+	})
+}
+
+// Clamp limits every value in m to [lo, hi] in place, replacing values below
+// lo with lo and values above hi with hi. Like [SortedMap.UpdateValues], it
+// walks the tree once without changing its structure.
+func Clamp[K any, V cmp.Ordered](m *SortedMap[K, V], lo, hi V) {
+	m.mustBeMutable()
+	m.inOrderNodes(m.root, func(n *node[K, V]) {
+		n.value = min(max(n.value, lo), hi)
+	})
+}
+
+// Compact deletes every entry whose value isEmpty reports as removable and
+// returns the number of entries removed. It is intended for maps used as
+// sparse accumulators, where keys should disappear once their value decays
+// to nothing. The surviving entries are relinked into a fresh balanced tree
+// in a single O(n) pass, except while an [SortedMap.OnChange] hook is set,
+// when each entry is removed with a Delete so that the hook sees it.
+func (m *SortedMap[K, V]) Compact(isEmpty func(V) bool) int {
+	m.mustBeMutable()
+	if m.onChange != nil {
+		var doomed []K
+		m.inOrder(m.root, func(k K, v V) bool {
+			if isEmpty(v) {
+				doomed = append(doomed, k)
+			}
+			return true
+		})
+		for _, k := range doomed {
+			m.Delete(k)
+		}
+		return len(doomed)
+	}
+	kept := make([]*node[K, V], 0, m.size)
+	m.inOrderNodes(m.root, func(n *node[K, V]) {
+		if !isEmpty(n.value) {
+			kept = append(kept, n)
+		}
+	})
+	removed := m.size - len(kept)
+	if removed > 0 {
+		m.relink(kept)
+	}
+	return removed
 }
 
 // Contains reports whether the map contains the given key.
@@ -102,6 +535,24 @@ func (m *SortedMap[K, V]) Contains(key K) bool {
 	return ok
 }
 
+// ContainsRange reports whether any key lies in [from, to] (inclusive). It
+// stops at the first in-range key found while descending the tree, so it
+// runs in O(log n) regardless of how many keys the range holds.
+func (m *SortedMap[K, V]) ContainsRange(from, to K) bool {
+	n := m.root
+	for n != nil {
+		switch {
+		case m.cmp(n.key, from) < 0:
+			n = n.right
+		case m.cmp(n.key, to) > 0:
+			n = n.left
+		default:
+			return true
+		}
+	}
+	return false
+}
+
 // Len returns the number of key-value pairs in the map.
 func (m *SortedMap[K, V]) Len() int { return m.size }
 
@@ -110,8 +561,61 @@ func (m *SortedMap[K, V]) IsEmpty() bool { return m.size == 0 }
 
 // Clear removes all key-value pairs from the map.
 func (m *SortedMap[K, V]) Clear() {
+	m.mustBeMutable()
 	m.root = nil
 	m.size = 0
+	m.version++
+}
+
+// FindValue returns the entry with the smallest key whose value satisfies
+// match, or zero values and false if there is none. Values are not indexed,
+// so this is a linear scan in ascending key order that stops at the first
+// match.
+func (m *SortedMap[K, V]) FindValue(match func(V) bool) (K, V, bool) {
+	var found *node[K, V]
+	m.inOrderNodesUntil(m.root, func(n *node[K, V]) bool {
+		if match(n.value) {
+			found = n
+			return false
+		}
+		return true
+	})
+	if found == nil {
+		var zk K
+		var zv V
+		return zk, zv, false
+	}
+	return found.key, found.value, true
+}
+
+// ContainsValue reports whether any entry's value equals v according to eq.
+// Like [SortedMap.FindValue], it is a linear scan that stops at the first
+// match.
+func (m *SortedMap[K, V]) ContainsValue(v V, eq func(a, b V) bool) bool {
+	_, _, ok := m.FindValue(func(x V) bool { return eq(x, v) })
+	return ok
+}
+
+// Freeze makes the map read-only. Afterwards every method that would modify
+// it, such as Put, Delete, or Clear, panics, while reads and iteration keep
+// working. A frozen map can therefore be shared freely between readers; use
+// [SortedMap.Clone] to get a mutable copy. Freezing cannot be undone.
+func (m *SortedMap[K, V]) Freeze() { m.frozen = true }
+
+// Frozen reports whether [SortedMap.Freeze] has been called on the map.
+func (m *SortedMap[K, V]) Frozen() bool { return m.frozen }
+
+// Clone returns an independent, mutable copy of the map with the same
+// comparison function. Change hooks are not copied. It runs in O(n) and
+// copies the tree's shape exactly.
+func (m *SortedMap[K, V]) Clone() *SortedMap[K, V] {
+	return &SortedMap[K, V]{root: cloneTree(m.root), size: m.size, cmp: m.cmp}
+}
+
+func (m *SortedMap[K, V]) mustBeMutable() {
+	if m.frozen {
+		panic("sortedmap: modification of frozen map")
+	}
 }
 
 // ---------- ordered operations ----------
@@ -140,6 +644,24 @@ func (m *SortedMap[K, V]) Max() (K, V, bool) {
 	return n.key, n.value, true
 }
 
+// MinEntry is like [SortedMap.Min] but returns the result as an [Entry].
+func (m *SortedMap[K, V]) MinEntry() (Entry[K, V], bool) {
+	if m.root == nil {
+		return Entry[K, V]{}, false
+	}
+	n := m.minNode(m.root)
+	return Entry[K, V]{Key: n.key, Value: n.value}, true
+}
+
+// MaxEntry is like [SortedMap.Max] but returns the result as an [Entry].
+func (m *SortedMap[K, V]) MaxEntry() (Entry[K, V], bool) {
+	if m.root == nil {
+		return Entry[K, V]{}, false
+	}
+	n := m.maxNode(m.root)
+	return Entry[K, V]{Key: n.key, Value: n.value}, true
+}
+
 // Floor returns the largest key less than or equal to the given key, along
 // with its value. If no such key exists it returns zero values and false.
 func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
@@ -164,6 +686,67 @@ func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
 	return n.key, n.value, true
 }
 
+// LowerBound returns the smallest key greater than or equal to key, like
+// C++'s std::lower_bound. It returns the zero key and false if every key is
+// less than key.
+func (m *SortedMap[K, V]) LowerBound(key K) (K, bool) {
+	k, _, ok := m.Ceiling(key)
+	return k, ok
+}
+
+// UpperBound returns the smallest key strictly greater than key, like C++'s
+// std::upper_bound. It returns the zero key and false if no key is greater
+// than key.
+func (m *SortedMap[K, V]) UpperBound(key K) (K, bool) {
+	n := m.higher(m.root, key)
+	if n == nil {
+		var zk K
+		return zk, false
+	}
+	return n.key, true
+}
+
+// FloorWithRank is like [SortedMap.Floor] but also returns the floor key's
+// rank, the number of keys smaller than it. The tree does not track subtree
+// sizes, so this walks the keys in order up to the floor and takes O(rank)
+// time.
+func (m *SortedMap[K, V]) FloorWithRank(key K) (K, V, int, bool) {
+	var floor *node[K, V]
+	rank := -1
+	m.inOrderNodesUntil(m.root, func(n *node[K, V]) bool {
+		if m.cmp(n.key, key) > 0 {
+			return false
+		}
+		floor = n
+		rank++
+		return true
+	})
+	if floor == nil {
+		var zk K
+		var zv V
+		return zk, zv, 0, false
+	}
+	return floor.key, floor.value, rank, true
+}
+
+// FloorEntry is like [SortedMap.Floor] but returns the result as an [Entry].
+func (m *SortedMap[K, V]) FloorEntry(key K) (Entry[K, V], bool) {
+	n := m.floor(m.root, key)
+	if n == nil {
+		return Entry[K, V]{}, false
+	}
+	return Entry[K, V]{Key: n.key, Value: n.value}, true
+}
+
+// CeilingEntry is like [SortedMap.Ceiling] but returns the result as an [Entry].
+func (m *SortedMap[K, V]) CeilingEntry(key K) (Entry[K, V], bool) {
+	n := m.ceiling(m.root, key)
+	if n == nil {
+		return Entry[K, V]{}, false
+	}
+	return Entry[K, V]{Key: n.key, Value: n.value}, true
+}
+
 // ---------- iteration ----------
 
 // All returns an iterator over all key-value pairs in ascending key order.
@@ -191,6 +774,16 @@ func (m *SortedMap[K, V]) Values() iter.Seq[V] {
 	}
 }
 
+// Entries returns an iterator over all entries in ascending key order, each
+// yielded as a single [Entry] value.
+func (m *SortedMap[K, V]) Entries() iter.Seq[Entry[K, V]] {
+	return func(yield func(Entry[K, V]) bool) {
+		m.inOrder(m.root, func(k K, v V) bool {
+			return yield(Entry[K, V]{Key: k, Value: v})
+		})
+	}
+}
+
 // Backward returns an iterator over all key-value pairs in descending key order.
 func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
@@ -198,6 +791,41 @@ func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
 	}
 }
 
+// KeysBackward returns an iterator over all keys in descending order.
+func (m *SortedMap[K, V]) KeysBackward() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.reverseInOrder(m.root, func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesBackward returns an iterator over all values in descending key order.
+func (m *SortedMap[K, V]) ValuesBackward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.reverseInOrder(m.root, func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Each calls f for each key-value pair in ascending key order, stopping
+// early if f returns false. It is the callback form of [SortedMap.All].
+func (m *SortedMap[K, V]) Each(f func(K, V) bool) {
+	m.inOrder(m.root, f)
+}
+
+// ForEachReverse calls f for each key-value pair in descending key order,
+// stopping at and returning the first non-nil error.
+func (m *SortedMap[K, V]) ForEachReverse(f func(K, V) error) error {
+	var err error
+	m.reverseInOrder(m.root, func(k K, v V) bool {
+		err = f(k, v)
+		return err == nil
+	})
+	return err
+}
+
 // Range returns an iterator over key-value pairs whose keys lie in [from, to]
 // (inclusive) in ascending order.
 func (m *SortedMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
@@ -206,17 +834,283 @@ func (m *SortedMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
 	}
 }
 
+// Seek returns an iterator over the key-value pairs whose keys are greater
+// than or equal to key, in ascending order, and reports whether key itself is
+// present and so will be the first pair yielded.
+func (m *SortedMap[K, V]) Seek(key K) (iter.Seq2[K, V], bool) {
+	n := m.ceiling(m.root, key)
+	found := n != nil && m.cmp(key, n.key) == 0
+	return func(yield func(K, V) bool) {
+		m.ascendFrom(m.root, key, yield)
+	}, found
+}
+
+// DrainRange returns an iterator that yields each key-value pair whose key
+// lies in [from, to] (inclusive), in ascending order, deleting each one from
+// the map as it is yielded. The range is collected before the first yield,
+// so the loop body may safely modify the map. If iteration stops early, the
+// entries not yet yielded stay in the map.
+func (m *SortedMap[K, V]) DrainRange(from, to K) iter.Seq2[K, V] {
+	m.mustBeMutable()
+	return func(yield func(K, V) bool) {
+		var entries []Entry[K, V]
+		m.rangeInOrder(m.root, from, to, func(k K, v V) bool {
+			entries = append(entries, Entry[K, V]{Key: k, Value: v})
+			return true
+		})
+		for _, e := range entries {
+			m.Delete(e.Key)
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// SubMap returns a new, independent SortedMap holding the entries whose keys
+// lie in [from, to] (inclusive), using the same comparison function. It is
+// built directly as a balanced tree from the in-range entries. m is not
+// modified.
+func (m *SortedMap[K, V]) SubMap(from, to K) *SortedMap[K, V] {
+	var entries []Entry[K, V]
+	m.rangeInOrder(m.root, from, to, func(k K, v V) bool {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+		return true
+	})
+	sub := &SortedMap[K, V]{cmp: m.cmp}
+	sub.load(entries)
+	return sub
+}
+
+// CopyRangeInto puts every entry of m whose key lies in [from, to]
+// (inclusive, by m's ordering) into dst, overwriting values for keys dst
+// already holds. Entries are placed by dst's comparison function, and each
+// costs one Put on dst, so the copy is O(k log(n+k)) for k copied entries
+// and runs dst's [SortedMap.OnChange] hook. m is not modified; copying a map
+// into itself is a no-op.
+func (m *SortedMap[K, V]) CopyRangeInto(from, to K, dst *SortedMap[K, V]) {
+	dst.mustBeMutable()
+	if dst == m {
+		return
+	}
+	m.rangeInOrder(m.root, from, to, func(k K, v V) bool {
+		dst.Put(k, v)
+		return true
+	})
+}
+
+// SplitFunc partitions m into two new, independent SortedMaps: matched
+// holds the entries for which pred returns true and rest holds the others.
+// Both use m's comparison function and are built directly as balanced trees
+// from their in-order entries. m is not modified.
+func (m *SortedMap[K, V]) SplitFunc(pred func(K, V) bool) (matched, rest *SortedMap[K, V]) {
+	var in, out []Entry[K, V]
+	m.inOrder(m.root, func(k K, v V) bool {
+		if pred(k, v) {
+			in = append(in, Entry[K, V]{Key: k, Value: v})
+		} else {
+			out = append(out, Entry[K, V]{Key: k, Value: v})
+		}
+		return true
+	})
+	matched = &SortedMap[K, V]{cmp: m.cmp}
+	matched.load(in)
+	rest = &SortedMap[K, V]{cmp: m.cmp}
+	rest.load(out)
+	return matched, rest
+}
+
+// LenRange returns the number of keys between from and to, with each bound
+// included or excluded according to fromInclusive and toInclusive; for
+// example, a half-open [from, to) window passes true, false. The tree keeps
+// no subtree sizes, so this visits each counted key, taking O(k + log n)
+// time for k matches, but allocates nothing.
+func (m *SortedMap[K, V]) LenRange(from, to K, fromInclusive, toInclusive bool) int {
+	return m.countRange(m.root, from, to, fromInclusive, toInclusive)
+}
+
+// KeysSlicePreSized returns all keys in ascending order as a slice
+// allocated once with exactly Len() elements, avoiding the repeated growth
+// of collecting [SortedMap.Keys] with append.
+func (m *SortedMap[K, V]) KeysSlicePreSized() []K {
+	keys := make([]K, 0, m.size)
+	m.inOrder(m.root, func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// KeysInRange returns the keys that lie in [from, to] (inclusive) as an
+// ascending slice.
+func (m *SortedMap[K, V]) KeysInRange(from, to K) []K {
+	var keys []K
+	m.rangeInOrder(m.root, from, to, func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// ValuesInRange returns the values whose keys lie in [from, to] (inclusive)
+// as a slice in ascending key order.
+func (m *SortedMap[K, V]) ValuesInRange(from, to K) []V {
+	var vals []V
+	m.rangeInOrder(m.root, from, to, func(_ K, v V) bool {
+		vals = append(vals, v)
+		return true
+	})
+	return vals
+}
+
+// AllFromLimit returns an iterator over at most limit key-value pairs whose
+// keys are greater than or equal to start, in ascending order. It is suited
+// to cursor-based paging: pass the key after the last one seen as start.
+func (m *SortedMap[K, V]) AllFromLimit(start K, limit int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if limit <= 0 {
+			return
+		}
+		n := 0
+		m.ascendFrom(m.root, start, func(k K, v V) bool {
+			if !yield(k, v) {
+				return false
+			}
+			n++
+			return n < limit
+		})
+	}
+}
+
+// FirstN returns the entries with the n smallest keys in ascending order,
+// or every entry if the map holds fewer than n. The traversal stops after n
+// entries.
+func (m *SortedMap[K, V]) FirstN(n int) []Entry[K, V] {
+	out := make([]Entry[K, V], 0, min(max(n, 0), m.size))
+	m.inOrder(m.root, func(k K, v V) bool {
+		if len(out) == cap(out) {
+			return false
+		}
+		out = append(out, Entry[K, V]{Key: k, Value: v})
+		return true
+	})
+	return out
+}
+
+// LastN returns the entries with the n largest keys, also in ascending
+// order, or every entry if the map holds fewer than n. It walks backward
+// from the largest key and stops after n entries.
+func (m *SortedMap[K, V]) LastN(n int) []Entry[K, V] {
+	out := make([]Entry[K, V], 0, min(max(n, 0), m.size))
+	m.reverseInOrder(m.root, func(k K, v V) bool {
+		if len(out) == cap(out) {
+			return false
+		}
+		out = append(out, Entry[K, V]{Key: k, Value: v})
+		return true
+	})
+	slices.Reverse(out)
+	return out
+}
+
+// ScanFrom returns up to maxN entries whose keys are strictly greater than
+// after, in ascending order, for chunked scans that cannot hold a live
+// iterator between calls. next is the cursor to pass as after on the
+// following call: the key of the last returned entry, or after itself if
+// none were returned. done reports whether no entries remain beyond the
+// returned chunk. Start a scan with [SortedMap.ScanFirst]. ScanFrom panics
+// if maxN is less than 1, since such a scan could never make progress.
+//
+// Because the cursor is a key rather than a position, entries may be added
+// or deleted between calls; each call sees the map as it is then.
+func (m *SortedMap[K, V]) ScanFrom(after K, maxN int) (entries []Entry[K, V], next K, done bool) {
+	if maxN < 1 {
+		panic("sortedmap: ScanFrom maxN must be at least 1")
+	}
+	return m.scan(maxN, after, func(yield func(K, V) bool) {
+		m.ascendFrom(m.root, after, func(k K, v V) bool {
+			return m.cmp(k, after) == 0 || yield(k, v)
+		})
+	})
+}
+
+// ScanFirst is like [SortedMap.ScanFrom] but starts at the smallest key. If
+// the map is empty, next is the zero value and done is true.
+func (m *SortedMap[K, V]) ScanFirst(maxN int) (entries []Entry[K, V], next K, done bool) {
+	if maxN < 1 {
+		panic("sortedmap: ScanFirst maxN must be at least 1")
+	}
+	var zero K
+	return m.scan(maxN, zero, func(yield func(K, V) bool) {
+		m.inOrder(m.root, yield)
+	})
+}
+
+// scan collects up to maxN entries from walk, peeking one further entry to
+// decide whether the scan is done.
+func (m *SortedMap[K, V]) scan(maxN int, cursor K, walk func(yield func(K, V) bool)) ([]Entry[K, V], K, bool) {
+	var entries []Entry[K, V]
+	done := true
+	walk(func(k K, v V) bool {
+		if len(entries) >= maxN {
+			done = false
+			return false
+		}
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+		cursor = k
+		return true
+	})
+	return entries, cursor, done
+}
+
+// EqualKeys reports whether a and b hold exactly the same keys, regardless
+// of their values. Keys are compared with a's comparison function while
+// walking both maps in lockstep, so the check is O(n).
+func EqualKeys[K, V, W any](a *SortedMap[K, V], b *SortedMap[K, W]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	next, stop := iter.Pull(b.Keys())
+	defer stop()
+	for k := range a.Keys() {
+		bk, ok := next()
+		if !ok || a.cmp(k, bk) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // String returns a human-readable representation of the map in key order.
+// Every entry is rendered; use [SortedMap.StringN] where the map may be
+// large, such as in logs.
 func (m *SortedMap[K, V]) String() string {
+	return m.StringN(m.size)
+}
+
+// StringN is like [SortedMap.String] but renders at most limit entries, the
+// smallest keys first, followed by "...(N more)" when entries were left out,
+// e.g. "{1: one, 2: two, ...(8 more)}".
+func (m *SortedMap[K, V]) StringN(limit int) string {
 	var b strings.Builder
 	b.WriteByte('{')
-	first := true
-	for k, v := range m.All() {
-		if !first {
+	n := 0
+	m.inOrder(m.root, func(k K, v V) bool {
+		if n >= limit {
+			return false
+		}
+		if n > 0 {
 			b.WriteString(", ")
 		}
 		fmt.Fprintf(&b, "%v: %v", k, v)
-		first = false
+		n++
+		return true
+	})
+	if rest := m.size - n; rest > 0 {
+		if n > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "...(%d more)", rest)
 	}
 	b.WriteByte('}')
 	return b.String()
@@ -227,6 +1121,7 @@ func (m *SortedMap[K, V]) String() string {
 func (m *SortedMap[K, V]) put(h *node[K, V], key K, value V) *node[K, V] {
 	if h == nil {
 		m.size++
+		m.version++
 		return &node[K, V]{key: key, value: value, color: red}
 	}
 	switch c := m.cmp(key, h.key); {
@@ -240,6 +1135,26 @@ func (m *SortedMap[K, V]) put(h *node[K, V], key K, value V) *node[K, V] {
 	return fixUp(h)
 }
 
+// upsert is like put but computes the stored value from the existing one,
+// if any, with fn.
+func (m *SortedMap[K, V]) upsert(h *node[K, V], key K, fn func(old V, exists bool) V) *node[K, V] {
+	if h == nil {
+		m.size++
+		m.version++
+		var zero V
+		return &node[K, V]{key: key, value: fn(zero, false), color: red}
+	}
+	switch c := m.cmp(key, h.key); {
+	case c < 0:
+		h.left = m.upsert(h.left, key, fn)
+	case c > 0:
+		h.right = m.upsert(h.right, key, fn)
+	default:
+		h.value = fn(h.value, true)
+	}
+	return fixUp(h)
+}
+
 func (m *SortedMap[K, V]) del(h *node[K, V], key K) *node[K, V] {
 	if m.cmp(key, h.key) < 0 {
 		if !isRed(h.left) && !isRed(h.left.left) {
@@ -279,6 +1194,20 @@ func (m *SortedMap[K, V]) deleteMin(h *node[K, V]) *node[K, V] {
 	return fixUp(h)
 }
 
+func (m *SortedMap[K, V]) deleteMax(h *node[K, V]) *node[K, V] {
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+	if h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+	h.right = m.deleteMax(h.right)
+	return fixUp(h)
+}
+
 func (m *SortedMap[K, V]) minNode(n *node[K, V]) *node[K, V] {
 	for n.left != nil {
 		n = n.left
@@ -293,6 +1222,38 @@ func (m *SortedMap[K, V]) maxNode(n *node[K, V]) *node[K, V] {
 	return n
 }
 
+// nodeAt returns the node with the i-th smallest key, or nil if i is out of
+// range.
+func (m *SortedMap[K, V]) nodeAt(i int) *node[K, V] {
+	if i < 0 || i >= m.size {
+		return nil
+	}
+	if i >= m.size/2 {
+		// Closer to the end: walk backward instead.
+		i = m.size - 1 - i
+		var found *node[K, V]
+		m.reverseInOrderNodes(m.root, func(n *node[K, V]) bool {
+			if i == 0 {
+				found = n
+				return false
+			}
+			i--
+			return true
+		})
+		return found
+	}
+	var found *node[K, V]
+	m.inOrderNodesUntil(m.root, func(n *node[K, V]) bool {
+		if i == 0 {
+			found = n
+			return false
+		}
+		i--
+		return true
+	})
+	return found
+}
+
 func (m *SortedMap[K, V]) floor(n *node[K, V], key K) *node[K, V] {
 	if n == nil {
 		return nil
@@ -310,6 +1271,20 @@ func (m *SortedMap[K, V]) floor(n *node[K, V], key K) *node[K, V] {
 	return n
 }
 
+// higher returns the node with the smallest key strictly greater than key.
+func (m *SortedMap[K, V]) higher(n *node[K, V], key K) *node[K, V] {
+	var best *node[K, V]
+	for n != nil {
+		if m.cmp(key, n.key) < 0 {
+			best = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return best
+}
+
 func (m *SortedMap[K, V]) ceiling(n *node[K, V], key K) *node[K, V] {
 	if n == nil {
 		return nil
@@ -327,6 +1302,69 @@ func (m *SortedMap[K, V]) ceiling(n *node[K, V], key K) *node[K, V] {
 	return n
 }
 
+// load replaces the contents of m with entries, which must already be
+// strictly ascending under m.cmp.
+func (m *SortedMap[K, V]) load(entries []Entry[K, V]) {
+	nodes := make([]node[K, V], len(entries))
+	ptrs := make([]*node[K, V], len(entries))
+	for i, e := range entries {
+		nodes[i] = node[K, V]{key: e.Key, value: e.Value}
+		ptrs[i] = &nodes[i]
+	}
+	m.relink(ptrs)
+}
+
+// relink rebuilds the tree from nodes, which must already be strictly
+// ascending by key. Existing node links and colors are overwritten.
+func (m *SortedMap[K, V]) relink(nodes []*node[K, V]) {
+	// Pick the largest black height h with 2^h-1 <= n; a 2-3 tree of that
+	// height can always hold n keys.
+	h, lo := 0, 0
+	for 2*lo+1 <= len(nodes) {
+		lo = 2*lo + 1
+		h++
+	}
+	m.root = build(nodes, h)
+	m.size = len(nodes)
+	m.version++
+}
+
+// build links nodes into an LLRB tree with black height h, viewing it as a
+// 2-3 tree: each level is either a 2-node (one black node) or a 3-node (a
+// black node with a red left child). len(nodes) must lie in [2^h-1, 3^h-1].
+func build[K, V any](nodes []*node[K, V], h int) *node[K, V] {
+	n := len(nodes)
+	if h == 0 {
+		return nil
+	}
+	// maxChild is the capacity of a 2-3 subtree of black height h-1.
+	maxChild := 1
+	for range h - 1 {
+		maxChild *= 3
+	}
+	maxChild--
+
+	if n-1 <= 2*maxChild {
+		mid := n / 2
+		root := nodes[mid]
+		root.left = build(nodes[:mid], h-1)
+		root.right = build(nodes[mid+1:], h-1)
+		root.color = black
+		return root
+	}
+	a := (n - 2) / 3
+	b := a + 1 + (n-2-a)/2
+	left := nodes[a]
+	left.left = build(nodes[:a], h-1)
+	left.right = build(nodes[a+1:b], h-1)
+	left.color = red
+	root := nodes[b]
+	root.left = left
+	root.right = build(nodes[b+1:], h-1)
+	root.color = black
+	return root
+}
+
 // ---------- traversal helpers ----------
 
 func (m *SortedMap[K, V]) inOrder(n *node[K, V], yield func(K, V) bool) bool {
@@ -338,6 +1376,35 @@ func (m *SortedMap[K, V]) inOrder(n *node[K, V], yield func(K, V) bool) bool {
 		m.inOrder(n.right, yield)
 }
 
+// inOrderNodes calls fn with every node in ascending key order.
+func (m *SortedMap[K, V]) inOrderNodes(n *node[K, V], fn func(*node[K, V])) {
+	for n != nil {
+		m.inOrderNodes(n.left, fn)
+		right := n.right
+		fn(n)
+		n = right
+	}
+}
+
+// inOrderNodesUntil is like inOrderNodes but stops once fn returns false.
+func (m *SortedMap[K, V]) inOrderNodesUntil(n *node[K, V], fn func(*node[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return m.inOrderNodesUntil(n.left, fn) &&
+		fn(n) &&
+		m.inOrderNodesUntil(n.right, fn)
+}
+
+func (m *SortedMap[K, V]) reverseInOrderNodes(n *node[K, V], fn func(*node[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return m.reverseInOrderNodes(n.right, fn) &&
+		fn(n) &&
+		m.reverseInOrderNodes(n.left, fn)
+}
+
 func (m *SortedMap[K, V]) reverseInOrder(n *node[K, V], yield func(K, V) bool) bool {
 	if n == nil {
 		return true
@@ -347,6 +1414,18 @@ func (m *SortedMap[K, V]) reverseInOrder(n *node[K, V], yield func(K, V) bool) b
 		m.reverseInOrder(n.left, yield)
 }
 
+func (m *SortedMap[K, V]) ascendFrom(n *node[K, V], from K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if m.cmp(from, n.key) > 0 {
+		return m.ascendFrom(n.right, from, yield)
+	}
+	return m.ascendFrom(n.left, from, yield) &&
+		yield(n.key, n.value) &&
+		m.inOrder(n.right, yield)
+}
+
 func (m *SortedMap[K, V]) rangeInOrder(n *node[K, V], from, to K, yield func(K, V) bool) bool {
 	if n == nil {
 		return true
@@ -371,6 +1450,35 @@ func (m *SortedMap[K, V]) rangeInOrder(n *node[K, V], from, to K, yield func(K,
 	return true
 }
 
+func (m *SortedMap[K, V]) countRange(n *node[K, V], from, to K, fromInc, toInc bool) int {
+	if n == nil {
+		return 0
+	}
+	cmpFrom := m.cmp(from, n.key)
+	cmpTo := m.cmp(to, n.key)
+	count := 0
+	if cmpFrom < 0 {
+		count += m.countRange(n.left, from, to, fromInc, toInc)
+	}
+	if (cmpFrom < 0 || fromInc && cmpFrom == 0) && (cmpTo > 0 || toInc && cmpTo == 0) {
+		count++
+	}
+	if cmpTo > 0 {
+		count += m.countRange(n.right, from, to, fromInc, toInc)
+	}
+	return count
+}
+
+func cloneTree[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.left = cloneTree(n.left)
+	c.right = cloneTree(n.right)
+	return &c
+}
+
 // ---------- red-black tree balancing ----------
 
 func rotateLeft[K, V any](h *node[K, V]) *node[K, V] {
@@ -2,7 +2,9 @@ package sortedmap
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
+	"maps"
 	"math/rand/v2"
 	"slices"
 	"testing"
@@ -48,6 +50,24 @@ func TestPutOverwrite(t *testing.T) {
 	assert.Equal(t, 1, m.Len(), "expected len 1 after overwrite")
 }
 
+func TestAppend(t *testing.T) {
+	m := New[string, []int]()
+	Append(m, "b", 1)
+	Append(m, "a", 2, 3)
+	Append(m, "b", 4, 5)
+	Append(m, "c")
+
+	require.Equal(t, 3, m.Len(), "expected 3 groups")
+	v, _ := m.Get("a")
+	assert.Equal(t, []int{2, 3}, v)
+	v, _ = m.Get("b")
+	assert.Equal(t, []int{1, 4, 5}, v)
+	v, ok := m.Get("c")
+	assert.True(t, ok, "Append with no values should still create the key")
+	assert.Empty(t, v)
+	checkLLRB(t, m)
+}
+
 func TestContains(t *testing.T) {
 	m := New[int, string]()
 	m.Put(1, "one")
@@ -55,6 +75,21 @@ func TestContains(t *testing.T) {
 	assert.False(t, m.Contains(2), "expected Contains(2) = false")
 }
 
+func TestGetAny(t *testing.T) {
+	m := New[string, int]()
+	m.Put("default", 1)
+	m.Put("user:42", 2)
+
+	k, v, ok := m.GetAny("user:42", "default")
+	assert.True(t, ok && k == "user:42" && v == 2, "GetAny = (%q, %d, %v)", k, v, ok)
+	k, v, ok = m.GetAny("user:7", "default")
+	assert.True(t, ok && k == "default" && v == 1, "GetAny = (%q, %d, %v)", k, v, ok)
+	_, _, ok = m.GetAny("user:7", "other")
+	assert.False(t, ok)
+	_, _, ok = m.GetAny()
+	assert.False(t, ok)
+}
+
 func TestDelete(t *testing.T) {
 	m := New[int, string]()
 	m.Put(1, "one")
@@ -108,6 +143,213 @@ func TestDeleteEvens(t *testing.T) {
 	}
 }
 
+func TestContainsRange(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		m.Put(k, "")
+	}
+	tests := []struct {
+		from, to int
+		want     bool
+	}{
+		{0, 9, false},
+		{0, 10, true},
+		{11, 19, false},
+		{15, 25, true},
+		{30, 30, true},
+		{31, 100, false},
+		{25, 15, false}, // empty range
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, m.ContainsRange(tc.from, tc.to), "ContainsRange(%d, %d)", tc.from, tc.to)
+	}
+	assert.False(t, New[int, int]().ContainsRange(0, 100), "ContainsRange on empty map should return false")
+}
+
+func TestKeyAt(t *testing.T) {
+	m := NewDescending[int, int]()
+	for i := range 9 {
+		m.Put(i, i)
+	}
+	keys := slices.Collect(m.Keys())
+	for i, want := range keys {
+		k, ok := m.KeyAt(i)
+		assert.True(t, ok)
+		assert.Equal(t, want, k, "KeyAt(%d)", i)
+	}
+	_, ok := m.KeyAt(-1)
+	assert.False(t, ok)
+	_, ok = m.KeyAt(9)
+	assert.False(t, ok)
+}
+
+func TestTrimFrontBack(t *testing.T) {
+	m := New[int, int]()
+	for i := range 20 {
+		m.Put(i, i)
+	}
+	assert.Equal(t, 5, m.TrimFront(15))
+	checkLLRB(t, m)
+	assert.Equal(t, 15, m.Len())
+	k, _, _ := m.Min()
+	assert.Equal(t, 5, k)
+
+	assert.Equal(t, 5, m.TrimBack(10))
+	checkLLRB(t, m)
+	assert.Equal(t, []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, slices.Collect(m.Keys()))
+
+	assert.Equal(t, 0, m.TrimFront(100), "expected nothing removed when under the bound")
+	assert.Equal(t, 10, m.TrimBack(-1))
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, 0, m.TrimFront(0))
+}
+
+func TestTrimStress(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	for range 100 {
+		m := New[int, int]()
+		for range rng.IntN(300) {
+			m.Put(rng.IntN(1000), 0)
+		}
+		keys := slices.Collect(m.Keys())
+		n := rng.IntN(len(keys) + 1)
+		if rng.IntN(2) == 0 {
+			require.Equal(t, len(keys)-n, m.TrimFront(n))
+			require.True(t, slices.Equal(keys[len(keys)-n:], slices.Collect(m.Keys())))
+		} else {
+			require.Equal(t, len(keys)-n, m.TrimBack(n))
+			require.True(t, slices.Equal(keys[:n], slices.Collect(m.Keys())))
+		}
+		require.Equal(t, n, m.Len())
+		checkLLRB(t, m)
+	}
+}
+
+func TestTrimFiresHook(t *testing.T) {
+	m := New[int, string]()
+	for i := range 4 {
+		m.Put(i, fmt.Sprint(i))
+	}
+	var got []string
+	m.OnChange(func(op ChangeOp, k int, old, _ string, existed bool) {
+		got = append(got, fmt.Sprintf("%v %d %s %v", op, k, old, existed))
+	})
+	m.TrimFront(3)
+	m.TrimBack(2)
+	assert.Equal(t, []string{"delete 0 0 true", "delete 3 3 true"}, got)
+}
+
+func TestDeleteRank(t *testing.T) {
+	m := New[int, string]()
+	for i := range 10 {
+		m.Put(i*10, fmt.Sprint(i))
+	}
+
+	k, v, ok := m.DeleteRank(2)
+	assert.True(t, ok && k == 20 && v == "2", "DeleteRank(2) = (%d, %q, %v), want (20, \"2\", true)", k, v, ok)
+	k, _, ok = m.DeleteRank(7) // 80 after 20 was removed
+	assert.True(t, ok && k == 80, "DeleteRank(7) = (%d, %v), want (80, true)", k, ok)
+	k, _, ok = m.DeleteRank(0)
+	assert.True(t, ok && k == 0, "DeleteRank(0) = (%d, %v), want (0, true)", k, ok)
+
+	_, _, ok = m.DeleteRank(-1)
+	assert.False(t, ok, "DeleteRank(-1) should return false")
+	_, _, ok = m.DeleteRank(m.Len())
+	assert.False(t, ok, "DeleteRank(Len()) should return false")
+
+	assert.Equal(t, []int{10, 30, 40, 50, 60, 70, 90}, slices.Collect(m.Keys()))
+	checkLLRB(t, m)
+}
+
+func TestUpdateValues(t *testing.T) {
+	m := New[int, float64]()
+	for i := range 5 {
+		m.Put(i, float64(i))
+	}
+	var visited []int
+	m.UpdateValues(func(k int, v float64) float64 {
+		visited = append(visited, k)
+		return v * 0.5
+	})
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, visited, "expected ascending visit order")
+	assert.Equal(t, []float64{0, 0.5, 1, 1.5, 2}, slices.Collect(m.Values()))
+	assert.Equal(t, 5, m.Len())
+
+	New[int, int]().UpdateValues(func(int, int) int {
+		t.Fatal("f should not be called on an empty map")
+		return 0
+	})
+}
+
+func TestClamp(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", -5)
+	m.Put("b", 3)
+	m.Put("c", 12)
+	m.Put("d", 10)
+	Clamp(m, 0, 10)
+	assert.Equal(t, []int{0, 3, 10, 10}, slices.Collect(m.Values()))
+	assert.Equal(t, 4, m.Len())
+}
+
+func TestCompact(t *testing.T) {
+	m := New[string, []int]()
+	m.Put("a", []int{1})
+	m.Put("b", nil)
+	m.Put("c", []int{})
+	m.Put("d", []int{2, 3})
+
+	removed := m.Compact(func(v []int) bool { return len(v) == 0 })
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []string{"a", "d"}, slices.Collect(m.Keys()))
+	checkLLRB(t, m)
+
+	assert.Equal(t, 0, m.Compact(func([]int) bool { return false }), "expected nothing removed")
+
+	counters := New[int, int]()
+	for i := range 100 {
+		counters.Put(i, i%3)
+	}
+	assert.Equal(t, 34, counters.Compact(func(v int) bool { return v == 0 }))
+	assert.Equal(t, 66, counters.Len())
+	checkLLRB(t, counters)
+}
+
+func TestFindValue(t *testing.T) {
+	m := New[int, string]()
+	m.Put(30, "ok")
+	m.Put(10, "pending")
+	m.Put(20, "ok")
+
+	visited := 0
+	k, v, ok := m.FindValue(func(s string) bool {
+		visited++
+		return s == "ok"
+	})
+	assert.True(t, ok && k == 20 && v == "ok", "FindValue = (%d, %q, %v), want (20, \"ok\", true)", k, v, ok)
+	assert.Equal(t, 2, visited, "expected FindValue to stop at the first match")
+
+	_, _, ok = m.FindValue(func(s string) bool { return s == "missing" })
+	assert.False(t, ok, "FindValue should return false when nothing matches")
+}
+
+func TestContainsValue(t *testing.T) {
+	m := New[string, []int]()
+	m.Put("a", []int{1, 2})
+	m.Put("b", []int{3})
+	m.Put("c", []int{3})
+
+	calls := 0
+	eq := func(a, b []int) bool {
+		calls++
+		return slices.Equal(a, b)
+	}
+	assert.True(t, m.ContainsValue([]int{3}, eq))
+	assert.Equal(t, 2, calls, "expected ContainsValue to stop at the first match")
+	assert.False(t, m.ContainsValue([]int{4}, eq))
+	assert.False(t, New[int, int]().ContainsValue(0, func(a, b int) bool { return a == b }))
+}
+
 func TestClear(t *testing.T) {
 	m := New[int, string]()
 	m.Put(1, "one")
@@ -138,6 +380,25 @@ func TestMinMax(t *testing.T) {
 	assert.False(t, !ok || k != 9 || v != "nine", "Max() = (%d, %q, %v), want (9, \"nine\", true)", k, v, ok)
 }
 
+func TestMinMaxEntry(t *testing.T) {
+	m := New[int, string]()
+	_, ok := m.MinEntry()
+	assert.False(t, ok, "MinEntry on empty map should return false")
+	_, ok = m.MaxEntry()
+	assert.False(t, ok, "MaxEntry on empty map should return false")
+
+	m.Put(5, "five")
+	m.Put(1, "one")
+	m.Put(9, "nine")
+
+	e, ok := m.MinEntry()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[int, string]{1, "one"}, e)
+	e, ok = m.MaxEntry()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[int, string]{9, "nine"}, e)
+}
+
 func TestFloor(t *testing.T) {
 	m := New[int, string]()
 	m.Put(2, "two")
@@ -194,6 +455,91 @@ func TestCeiling(t *testing.T) {
 	}
 }
 
+func TestLowerUpperBound(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		m.Put(k, "v")
+	}
+	for _, tc := range []struct {
+		key              int
+		lower, upper     int
+		lowerOK, upperOK bool
+	}{
+		{5, 10, 10, true, true},
+		{10, 10, 20, true, true},
+		{15, 20, 20, true, true},
+		{30, 30, 0, true, false},
+		{31, 0, 0, false, false},
+	} {
+		k, ok := m.LowerBound(tc.key)
+		assert.Equal(t, tc.lowerOK, ok, "LowerBound(%d)", tc.key)
+		assert.Equal(t, tc.lower, k, "LowerBound(%d)", tc.key)
+		k, ok = m.UpperBound(tc.key)
+		assert.Equal(t, tc.upperOK, ok, "UpperBound(%d)", tc.key)
+		assert.Equal(t, tc.upper, k, "UpperBound(%d)", tc.key)
+	}
+
+	_, ok := New[int, int]().UpperBound(0)
+	assert.False(t, ok)
+}
+
+func TestFloorWithRank(t *testing.T) {
+	m := New[int, string]()
+	m.Put(2, "two")
+	m.Put(4, "four")
+	m.Put(6, "six")
+
+	tests := []struct {
+		key      int
+		wantKey  int
+		wantVal  string
+		wantRank int
+		wantOK   bool
+	}{
+		{1, 0, "", 0, false},
+		{2, 2, "two", 0, true},
+		{3, 2, "two", 0, true},
+		{5, 4, "four", 1, true},
+		{6, 6, "six", 2, true},
+		{99, 6, "six", 2, true},
+	}
+	for _, tc := range tests {
+		k, v, r, ok := m.FloorWithRank(tc.key)
+		assert.False(t, ok != tc.wantOK || k != tc.wantKey || v != tc.wantVal || r != tc.wantRank,
+			"FloorWithRank(%d) = (%d, %q, %d, %v), want (%d, %q, %d, %v)",
+			tc.key, k, v, r, ok, tc.wantKey, tc.wantVal, tc.wantRank, tc.wantOK)
+	}
+}
+
+func TestFloorCeilingEntry(t *testing.T) {
+	m := New[int, string]()
+	m.Put(2, "two")
+	m.Put(4, "four")
+	m.Put(6, "six")
+
+	var got []Entry[int, string]
+	for _, k := range []int{1, 3, 6, 99} {
+		if e, ok := m.FloorEntry(k); ok {
+			got = append(got, e)
+		}
+	}
+	assert.Equal(t, []Entry[int, string]{{2, "two"}, {6, "six"}, {6, "six"}}, got)
+
+	got = got[:0]
+	for _, k := range []int{1, 3, 6, 99} {
+		if e, ok := m.CeilingEntry(k); ok {
+			got = append(got, e)
+		}
+	}
+	assert.Equal(t, []Entry[int, string]{{2, "two"}, {4, "four"}, {6, "six"}}, got)
+
+	e, ok := New[int, string]().FloorEntry(1)
+	assert.False(t, ok, "FloorEntry on empty map should return false")
+	assert.Equal(t, Entry[int, string]{}, e)
+	_, ok = New[int, string]().CeilingEntry(1)
+	assert.False(t, ok, "CeilingEntry on empty map should return false")
+}
+
 // ---------- iteration ----------
 
 func TestAll(t *testing.T) {
@@ -253,6 +599,14 @@ func TestValues(t *testing.T) {
 	assert.True(t, slices.Equal(vals, []string{"one", "two", "three"}), "Values = %v, want [one two three]", vals)
 }
 
+func TestEntries(t *testing.T) {
+	m := New[int, string]()
+	m.Put(2, "two")
+	m.Put(1, "one")
+	assert.Equal(t, []Entry[int, string]{{1, "one"}, {2, "two"}}, slices.Collect(m.Entries()))
+	assert.Empty(t, slices.Collect(New[int, int]().Entries()))
+}
+
 func TestBackward(t *testing.T) {
 	m := New[int, string]()
 	m.Put(1, "one")
@@ -266,6 +620,64 @@ func TestBackward(t *testing.T) {
 	assert.True(t, slices.Equal(keys, []int{3, 2, 1}), "Backward keys = %v, want [3 2 1]", keys)
 }
 
+func TestKeysValuesBackward(t *testing.T) {
+	m := New[int, string]()
+	m.Put(2, "two")
+	m.Put(1, "one")
+	m.Put(3, "three")
+
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(m.KeysBackward()))
+	assert.Equal(t, []string{"three", "two", "one"}, slices.Collect(m.ValuesBackward()))
+
+	var keys []int
+	for k := range m.KeysBackward() {
+		keys = append(keys, k)
+		break
+	}
+	assert.Equal(t, []int{3}, keys, "expected iteration to stop on break")
+}
+
+func TestEach(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{3, 1, 2} {
+		m.Put(k, fmt.Sprint(k))
+	}
+	var keys []int
+	m.Each(func(k int, v string) bool {
+		assert.Equal(t, fmt.Sprint(k), v)
+		keys = append(keys, k)
+		return k < 2
+	})
+	assert.Equal(t, []int{1, 2}, keys, "expected Each to visit ascending and stop when f returns false")
+}
+
+func TestForEachReverse(t *testing.T) {
+	m := New[int, int]()
+	for i := range 5 {
+		m.Put(i, i*10)
+	}
+
+	var keys []int
+	err := m.ForEachReverse(func(k, v int) error {
+		keys = append(keys, k)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 3, 2, 1, 0}, keys)
+
+	errStop := errors.New("stop")
+	keys = keys[:0]
+	err = m.ForEachReverse(func(k, v int) error {
+		keys = append(keys, k)
+		if v == 30 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, []int{4, 3}, keys, "expected ForEachReverse to stop at the first error")
+}
+
 func TestRange(t *testing.T) {
 	m := New[int, string]()
 	for i := 1; i <= 10; i++ {
@@ -304,6 +716,247 @@ func TestRangeSingleElement(t *testing.T) {
 	assert.True(t, slices.Equal(keys, []int{5}), "Range(5,5) = %v, want [5]", keys)
 }
 
+func TestSubMap(t *testing.T) {
+	m := NewDescending[int, string]()
+	for i := range 10 {
+		m.Put(i, fmt.Sprint(i))
+	}
+	sub := m.SubMap(7, 4)
+	checkLLRB(t, sub)
+	assert.Equal(t, []int{7, 6, 5, 4}, slices.Collect(sub.Keys()), "SubMap should keep the comparator")
+
+	sub.Put(100, "x")
+	sub.Delete(5)
+	assert.Equal(t, 10, m.Len(), "original should be unaffected by changes to the sub-map")
+	assert.True(t, m.Contains(5))
+
+	assert.True(t, m.SubMap(20, 15).IsEmpty())
+}
+
+func TestCopyRangeInto(t *testing.T) {
+	a := New[int, string]()
+	b := New[int, string]()
+	for i := range 10 {
+		a.Put(i, "a")
+		b.Put(i*10, "b")
+	}
+	dst := NewDescending[int, string]()
+	dst.Put(3, "old")
+	dst.Put(100, "keep")
+	a.CopyRangeInto(2, 4, dst)
+	b.CopyRangeInto(50, 70, dst)
+	checkLLRB(t, dst)
+	assert.Equal(t, []Entry[int, string]{
+		{100, "keep"}, {70, "b"}, {60, "b"}, {50, "b"}, {4, "a"}, {3, "a"}, {2, "a"},
+	}, slices.Collect(dst.Entries()), "expected dst's ordering and overwrite on collision")
+	assert.Equal(t, 7, dst.Len())
+	assert.Equal(t, 10, a.Len(), "source should be unaffected")
+
+	a.CopyRangeInto(0, 9, a)
+	assert.Equal(t, 10, a.Len())
+
+	dst.Freeze()
+	assert.Panics(t, func() { a.CopyRangeInto(0, 1, dst) })
+}
+
+func TestSplitFunc(t *testing.T) {
+	m := NewDescending[int, string]()
+	for i := range 10 {
+		m.Put(i, fmt.Sprint(i))
+	}
+	even, odd := m.SplitFunc(func(k int, _ string) bool { return k%2 == 0 })
+	checkLLRB(t, even)
+	checkLLRB(t, odd)
+	assert.Equal(t, []int{8, 6, 4, 2, 0}, slices.Collect(even.Keys()), "SplitFunc should keep the comparator")
+	assert.Equal(t, []int{9, 7, 5, 3, 1}, slices.Collect(odd.Keys()))
+	assert.Equal(t, 5, even.Len())
+
+	even.Put(100, "x")
+	odd.Delete(9)
+	assert.Equal(t, 10, m.Len(), "original should be unaffected by changes to the parts")
+	assert.True(t, m.Contains(9))
+
+	all, none := m.SplitFunc(func(int, string) bool { return true })
+	assert.Equal(t, 10, all.Len())
+	assert.True(t, none.IsEmpty())
+	none.Put(1, "ok")
+	assert.Equal(t, 1, none.Len(), "expected an empty part to be usable")
+}
+
+func TestLenRange(t *testing.T) {
+	m := New[int, int]()
+	for i := range 10 {
+		m.Put(i*10, i) // 0, 10, ..., 90
+	}
+	tests := []struct {
+		from, to       int
+		fromInc, toInc bool
+		want           int
+	}{
+		{20, 50, true, true, 4},
+		{20, 50, true, false, 3},
+		{20, 50, false, true, 3},
+		{20, 50, false, false, 2},
+		{15, 55, false, false, 4},
+		{50, 50, true, true, 1},
+		{50, 50, true, false, 0},
+		{-100, 1000, true, true, 10},
+		{60, 20, true, true, 0},
+	}
+	for _, tc := range tests {
+		got := m.LenRange(tc.from, tc.to, tc.fromInc, tc.toInc)
+		assert.Equal(t, tc.want, got, "LenRange(%d, %d, %v, %v)", tc.from, tc.to, tc.fromInc, tc.toInc)
+	}
+	assert.Equal(t, 0, New[int, int]().LenRange(0, 10, true, true))
+}
+
+func TestKeysSlicePreSized(t *testing.T) {
+	m := New[int, int]()
+	for _, k := range []int{5, 1, 3} {
+		m.Put(k, k)
+	}
+	keys := m.KeysSlicePreSized()
+	assert.Equal(t, []int{1, 3, 5}, keys)
+	assert.Equal(t, 3, cap(keys), "expected exactly Len() capacity")
+	assert.Empty(t, New[int, int]().KeysSlicePreSized())
+}
+
+func TestKeysInRange(t *testing.T) {
+	m := New[int, string]()
+	for i := 1; i <= 10; i++ {
+		m.Put(i*10, "")
+	}
+	assert.Equal(t, []int{30, 40, 50}, m.KeysInRange(25, 50))
+	assert.Equal(t, []int{10}, m.KeysInRange(0, 10))
+	assert.Empty(t, m.KeysInRange(11, 19))
+	assert.Empty(t, New[int, int]().KeysInRange(0, 100))
+}
+
+func TestValuesInRange(t *testing.T) {
+	m := New[int, float64]()
+	for i := 1; i <= 10; i++ {
+		m.Put(i*10, float64(i)/2)
+	}
+	assert.Equal(t, []float64{1.5, 2, 2.5}, m.ValuesInRange(25, 50))
+	assert.Empty(t, m.ValuesInRange(11, 19))
+	assert.Empty(t, New[int, int]().ValuesInRange(0, 100))
+}
+
+func TestDrainRange(t *testing.T) {
+	m := New[int, int]()
+	for i := range 10 {
+		m.Put(i, i*10)
+	}
+	var drained []Entry[int, int]
+	for k, v := range m.DrainRange(3, 6) {
+		drained = append(drained, Entry[int, int]{k, v})
+	}
+	assert.Equal(t, []Entry[int, int]{{3, 30}, {4, 40}, {5, 50}, {6, 60}}, drained)
+	assert.Equal(t, []int{0, 1, 2, 7, 8, 9}, slices.Collect(m.Keys()))
+	checkLLRB(t, m)
+
+	for k := range m.DrainRange(0, 9) {
+		if k == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{2, 7, 8, 9}, slices.Collect(m.Keys()), "entries after an early break should remain")
+}
+
+func TestAllFromLimit(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 20; i += 2 {
+		m.Put(i, i*10)
+	}
+
+	tests := []struct {
+		start, limit int
+		want         []int
+	}{
+		{0, 3, []int{0, 2, 4}},
+		{5, 3, []int{6, 8, 10}},     // start between keys
+		{14, 10, []int{14, 16, 18}}, // limit past end
+		{-5, 1, []int{0}},
+		{19, 5, nil},
+		{4, 0, nil},
+		{4, -1, nil},
+	}
+	for _, tc := range tests {
+		var keys []int
+		for k, v := range m.AllFromLimit(tc.start, tc.limit) {
+			assert.Equal(t, k*10, v)
+			keys = append(keys, k)
+		}
+		assert.Equal(t, tc.want, keys, "AllFromLimit(%d, %d)", tc.start, tc.limit)
+	}
+}
+
+func TestSeek(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		m.Put(k, fmt.Sprint(k))
+	}
+
+	seq, found := m.Seek(20)
+	assert.True(t, found, "Seek(20) should report an exact match")
+	var keys []int
+	for k := range seq {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{20, 30}, keys)
+
+	seq, found = m.Seek(15)
+	assert.False(t, found, "Seek(15) should not report an exact match")
+	keys = keys[:0]
+	for k := range seq {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{20, 30}, keys)
+
+	seq, found = m.Seek(31)
+	assert.False(t, found)
+	for range seq {
+		t.Fatal("Seek past the end should yield nothing")
+	}
+}
+
+func TestAllFromLimitPaging(t *testing.T) {
+	m := New[int, int]()
+	for i := range 10 {
+		m.Put(i, i)
+	}
+	var pages [][]int
+	cursor := 0
+	for {
+		var page []int
+		for k := range m.AllFromLimit(cursor, 4) {
+			page = append(page, k)
+		}
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page)
+		cursor = page[len(page)-1] + 1
+	}
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, pages)
+}
+
+func TestEqualKeys(t *testing.T) {
+	a := New[int, string]()
+	b := New[int, float64]()
+	for i := range 5 {
+		a.Put(i, fmt.Sprint(i))
+		b.Put(i, float64(i)/2)
+	}
+	assert.True(t, EqualKeys(a, b), "expected maps with same keys to match")
+
+	b.Put(5, 0)
+	assert.False(t, EqualKeys(a, b), "expected maps of different size to differ")
+	b.Delete(0)
+	assert.False(t, EqualKeys(a, b), "expected maps with different keys to differ")
+	assert.True(t, EqualKeys(New[int, int](), New[int, bool]()), "expected empty maps to match")
+}
+
 // ---------- String ----------
 
 func TestString(t *testing.T) {
@@ -319,8 +972,48 @@ func TestStringEmpty(t *testing.T) {
 	assert.Equal(t, "{}", m.String())
 }
 
+func TestStringN(t *testing.T) {
+	m := New[int, string]()
+	for i := 1; i <= 10; i++ {
+		m.Put(i, fmt.Sprint(i))
+	}
+	assert.Equal(t, "{1: 1, 2: 2, ...(8 more)}", m.StringN(2))
+	assert.Equal(t, "{...(10 more)}", m.StringN(0))
+	assert.Equal(t, "{...(10 more)}", m.StringN(-1))
+	assert.Equal(t, m.String(), m.StringN(10))
+	assert.Equal(t, m.String(), m.StringN(100))
+	assert.Equal(t, "{}", New[int, int]().StringN(3))
+}
+
 // ---------- custom comparator ----------
 
+func TestNewWithCompareChecked(t *testing.T) {
+	m := NewWithCompareChecked[int, string](cmp.Compare[int])
+	for i := range 100 {
+		m.Put(i*7%100, "v")
+	}
+	checkLLRB(t, m)
+	assert.Equal(t, 100, m.Len())
+
+	// Always reporting "greater" makes compare(a, b) and compare(b, a) agree.
+	broken := NewWithCompareChecked[int, string](func(a, b int) int { return 1 })
+	broken.Put(1, "a")
+	assert.PanicsWithValue(t,
+		"sortedmap: inconsistent comparator: compare(2, 1) = 1 but compare(1, 2) = 1",
+		func() { broken.Put(2, "b") })
+
+	// A "less or equal" comparator is antisymmetric for distinct keys but not
+	// reflexive.
+	nonReflexive := NewWithCompareChecked[int, string](func(a, b int) int {
+		if a <= b {
+			return -1
+		}
+		return 1
+	})
+	nonReflexive.Put(1, "a")
+	assert.Panics(t, func() { nonReflexive.Put(1, "b") })
+}
+
 func TestNewWithCompare(t *testing.T) {
 	// Reverse ordering.
 	m := NewWithCompare[int, string](func(a, b int) int {
@@ -342,6 +1035,37 @@ func TestNewWithCompare(t *testing.T) {
 	assert.Equal(t, 1, k, "Max key with reverse comparator")
 }
 
+func TestNewDescending(t *testing.T) {
+	m := NewDescending[int, string]()
+	m.Put(1, "one")
+	m.Put(3, "three")
+	m.Put(2, "two")
+
+	var keys []int
+	for k := range m.Keys() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{3, 2, 1}, keys, "descending Keys")
+	k, _, _ := m.Min()
+	assert.Equal(t, 3, k, "Min key of descending map")
+	k, _, _ = m.Floor(0)
+	assert.Equal(t, 1, k, "Floor(0) of descending map")
+}
+
+func TestReverse(t *testing.T) {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	m := NewWithCompare[string, int](Reverse(byLen))
+	m.Put("a", 1)
+	m.Put("ccc", 3)
+	m.Put("bb", 2)
+
+	var keys []string
+	for k := range m.Keys() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"ccc", "bb", "a"}, keys, "Reverse(byLen) Keys")
+}
+
 // ---------- string keys ----------
 
 func TestStringKeys(t *testing.T) {
@@ -435,6 +1159,327 @@ func TestRandomInsertDelete(t *testing.T) {
 	}
 }
 
+// ---------- bulk load ----------
+
+// checkLLRB verifies the left-leaning red-black invariants and the cached
+// size of m.
+func checkLLRB[K, V any](t *testing.T, m *SortedMap[K, V]) {
+	t.Helper()
+	require.False(t, isRed(m.root), "root must be black")
+	var count int
+	var walk func(n *node[K, V]) int
+	walk = func(n *node[K, V]) int {
+		if n == nil {
+			return 0
+		}
+		count++
+		require.False(t, isRed(n.right), "red right link at %v", n.key)
+		require.False(t, isRed(n) && isRed(n.left), "consecutive red links at %v", n.key)
+		if n.left != nil {
+			require.Negative(t, m.cmp(n.left.key, n.key), "left child out of order at %v", n.key)
+		}
+		if n.right != nil {
+			require.Positive(t, m.cmp(n.right.key, n.key), "right child out of order at %v", n.key)
+		}
+		lh, rh := walk(n.left), walk(n.right)
+		require.Equal(t, lh, rh, "unbalanced black height at %v", n.key)
+		if !isRed(n) {
+			lh++
+		}
+		return lh
+	}
+	walk(m.root)
+	require.Equal(t, count, m.Len(), "cached size does not match node count")
+}
+
+func TestFromSorted(t *testing.T) {
+	for n := range 200 {
+		entries := make([]Entry[int, int], n)
+		for i := range entries {
+			entries[i] = Entry[int, int]{Key: i * 2, Value: i}
+		}
+		m := FromSorted(entries)
+		checkLLRB(t, m)
+
+		var got []Entry[int, int]
+		for k, v := range m.All() {
+			got = append(got, Entry[int, int]{k, v})
+		}
+		require.Equal(t, len(entries), len(got), "n=%d", n)
+		if n > 0 {
+			require.Equal(t, entries, got, "n=%d", n)
+		}
+
+		// The loaded tree must keep working under regular mutation.
+		m.Put(-1, -1)
+		m.Put(n*2+1, n)
+		for i := 0; i < n; i += 3 {
+			m.Delete(i * 2)
+		}
+		checkLLRB(t, m)
+	}
+}
+
+func TestFromSortedWithCompare(t *testing.T) {
+	m := FromSortedWithCompare([]Entry[int, string]{{3, "c"}, {2, "b"}, {1, "a"}},
+		func(a, b int) int { return cmp.Compare(b, a) })
+	checkLLRB(t, m)
+	k, _, _ := m.Min()
+	assert.Equal(t, 3, k, "Min key with reverse comparator")
+}
+
+func TestFromSortedUnsorted(t *testing.T) {
+	assert.Panics(t, func() { FromSorted([]Entry[int, int]{{2, 0}, {1, 0}}) })
+	assert.Panics(t, func() { FromSorted([]Entry[int, int]{{1, 0}, {1, 0}}) })
+}
+
+func TestPutBatch(t *testing.T) {
+	for _, existing := range []int{0, 10, 1000} {
+		m := New[int, int]()
+		ref := make(map[int]int)
+		for i := range existing {
+			m.Put(i*2, i)
+			ref[i*2] = i
+		}
+		batch := []Entry[int, int]{{5, 1}, {3, 1}, {4, 1}, {5, 2}, {-1, 1}, {3, 3}}
+		for _, e := range batch {
+			ref[e.Key] = e.Value
+		}
+		orig := slices.Clone(batch)
+
+		m.PutBatch(batch)
+		checkLLRB(t, m)
+		assert.Equal(t, orig, batch, "PutBatch must not modify its argument")
+		require.Equal(t, len(ref), m.Len(), "existing=%d: size mismatch", existing)
+		for k, want := range ref {
+			got, ok := m.Get(k)
+			assert.True(t, ok && got == want, "existing=%d: Get(%d) = (%d, %v), want %d", existing, k, got, ok, want)
+		}
+	}
+}
+
+func TestPutBatchLarge(t *testing.T) {
+	m := New[int, int]()
+	for i := range 100 {
+		m.Put(i, 0)
+	}
+	batch := make([]Entry[int, int], 0, 200)
+	for i := 199; i >= 0; i-- {
+		batch = append(batch, Entry[int, int]{Key: i, Value: i})
+	}
+	m.PutBatch(batch)
+	checkLLRB(t, m)
+	require.Equal(t, 200, m.Len())
+	for k, v := range m.All() {
+		require.Equal(t, k, v)
+	}
+}
+
+func TestDeleteAllKeys(t *testing.T) {
+	m := New[int, string]()
+	for i := range 10 {
+		m.Put(i, "v")
+	}
+	keys := []int{9, 3, 3, 42, -1}
+	orig := slices.Clone(keys)
+	assert.Equal(t, 2, m.DeleteAll(keys...), "expected duplicates and absent keys to be ignored")
+	assert.Equal(t, orig, keys, "DeleteAll must not modify its argument")
+	assert.Equal(t, []int{0, 1, 2, 4, 5, 6, 7, 8}, slices.Collect(m.Keys()))
+	checkLLRB(t, m)
+
+	assert.Equal(t, 0, m.DeleteAll())
+	assert.Equal(t, 8, m.DeleteAll(slices.Collect(m.Keys())...))
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, 0, m.DeleteAll(1))
+}
+
+func TestDeleteAllStress(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for round := range 200 {
+		m := New[int, int]()
+		ref := make(map[int]int)
+		for range rng.IntN(500) {
+			k := rng.IntN(1000)
+			m.Put(k, k)
+			ref[k] = k
+		}
+		// Alternate between batches small enough for individual Deletes and
+		// large enough to trigger a rebuild.
+		keys := make([]int, rng.IntN(1+len(ref)/(1+round%8)))
+		for i := range keys {
+			keys[i] = rng.IntN(1000)
+		}
+		want := 0
+		for _, k := range keys {
+			if _, ok := ref[k]; ok {
+				delete(ref, k)
+				want++
+			}
+		}
+		require.Equal(t, want, m.DeleteAll(keys...), "round %d", round)
+		require.Equal(t, len(ref), m.Len(), "round %d", round)
+		checkLLRB(t, m)
+		for k := range m.Keys() {
+			_, ok := ref[k]
+			require.True(t, ok, "round %d: unexpected key %d", round, k)
+		}
+	}
+}
+
+func TestFirstNLastN(t *testing.T) {
+	m := New[int, string]()
+	for i := range 10 {
+		m.Put(i, fmt.Sprint(i))
+	}
+	assert.Equal(t, []Entry[int, string]{{0, "0"}, {1, "1"}, {2, "2"}}, m.FirstN(3))
+	assert.Equal(t, []Entry[int, string]{{7, "7"}, {8, "8"}, {9, "9"}}, m.LastN(3))
+	assert.Len(t, m.FirstN(100), 10)
+	assert.Equal(t, slices.Collect(m.Entries()), m.LastN(100))
+	assert.Empty(t, m.FirstN(0))
+	assert.Empty(t, m.LastN(-1))
+	assert.Empty(t, New[int, int]().LastN(5))
+}
+
+func TestScanFrom(t *testing.T) {
+	m := New[int, int]()
+	for i := 1; i <= 10; i++ {
+		m.Put(i*10, i)
+	}
+
+	var got []int
+	entries, next, done := m.ScanFirst(4)
+	for {
+		for _, e := range entries {
+			got = append(got, e.Key)
+		}
+		if done {
+			break
+		}
+		entries, next, done = m.ScanFrom(next, 4)
+	}
+	assert.Equal(t, slices.Collect(m.Keys()), got)
+	assert.Equal(t, 100, next)
+
+	entries, next, done = m.ScanFrom(35, 2)
+	assert.Equal(t, []Entry[int, int]{{40, 4}, {50, 5}}, entries)
+	assert.Equal(t, 50, next)
+	assert.False(t, done)
+
+	entries, next, done = m.ScanFrom(90, 1)
+	assert.Equal(t, []Entry[int, int]{{100, 10}}, entries)
+	assert.Equal(t, 100, next)
+	assert.True(t, done, "expected done when the chunk ends exactly at the last key")
+
+	entries, next, done = m.ScanFrom(100, 5)
+	assert.Empty(t, entries)
+	assert.Equal(t, 100, next)
+	assert.True(t, done)
+
+	assert.Panics(t, func() { m.ScanFrom(0, 0) }, "expected maxN 0 to panic rather than never finish")
+	assert.Panics(t, func() { m.ScanFirst(-1) })
+}
+
+func TestScanFromConcurrentEdits(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{1, 2, 3, 4} {
+		m.Put(k, "v")
+	}
+	entries, next, _ := m.ScanFirst(2)
+	require.Len(t, entries, 2)
+	m.Delete(2)
+	m.Delete(3)
+	m.Put(5, "v")
+	entries, _, done := m.ScanFrom(next, 10)
+	assert.Equal(t, []Entry[int, string]{{4, "v"}, {5, "v"}}, entries)
+	assert.True(t, done)
+
+	_, _, done = New[int, int]().ScanFirst(1)
+	assert.True(t, done)
+}
+
+func TestMergeSeq(t *testing.T) {
+	dst := New[string, int]()
+	dst.Put("a", 1)
+	dst.Put("b", 2)
+	src := New[string, int]()
+	src.Put("b", 10)
+	src.Put("c", 20)
+	src.Put("z", 99)
+
+	sum := func(_ string, old, new int) int { return old + new }
+	dst.MergeSeq(src.Range("a", "c"), sum)
+	assert.Equal(t, []Entry[string, int]{{"a", 1}, {"b", 12}, {"c", 20}}, slices.Collect(dst.Entries()))
+	checkLLRB(t, dst)
+
+	unordered := func(yield func(string, int) bool) {
+		for _, e := range []Entry[string, int]{{"d", 1}, {"a", 5}, {"d", 2}} {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+	dst.MergeSeq(unordered, sum)
+	assert.Equal(t, []Entry[string, int]{{"a", 6}, {"b", 12}, {"c", 20}, {"d", 3}}, slices.Collect(dst.Entries()))
+	assert.Equal(t, 4, dst.Len())
+	checkLLRB(t, dst)
+}
+
+func TestMergeSeqFiresHook(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 1)
+	var got []string
+	m.OnChange(func(op ChangeOp, k, old, new int, existed bool) {
+		got = append(got, fmt.Sprintf("%v %d %d->%d %v", op, k, old, new, existed))
+	})
+	m.MergeSeq(maps.All(map[int]int{1: 5}), func(_, old, new int) int { return old * new })
+	assert.Equal(t, []string{"put 1 1->5 true"}, got)
+}
+
+func TestPutKeys(t *testing.T) {
+	for _, existing := range []int{0, 100} {
+		m := New[int, bool]()
+		ref := make(map[int]bool)
+		for i := range existing {
+			m.Put(i, true)
+			ref[i] = true
+		}
+		keys := []int{5, 200, 3, 200, -1}
+		for _, k := range keys {
+			ref[k] = false
+		}
+		orig := slices.Clone(keys)
+
+		m.PutKeys(false, keys...)
+		checkLLRB(t, m)
+		assert.Equal(t, orig, keys, "PutKeys must not modify its argument")
+		require.Equal(t, len(ref), m.Len(), "existing=%d: size mismatch", existing)
+		for k, want := range ref {
+			got, ok := m.Get(k)
+			assert.True(t, ok && got == want, "existing=%d: Get(%d) = (%v, %v), want %v", existing, k, got, ok, want)
+		}
+	}
+}
+
+func TestPutAllMap(t *testing.T) {
+	src := map[int]string{1: "one", 500: "five hundred", -1: "minus one"}
+	for _, tc := range []struct{ existing, wantLen int }{
+		{0, 3},     // rebuild path
+		{100, 102}, // individual Puts; key 1 is updated
+	} {
+		m := New[int, string]()
+		for i := range tc.existing {
+			m.Put(i, "old")
+		}
+		PutAllMap(m, src)
+		checkLLRB(t, m)
+		require.Equal(t, tc.wantLen, m.Len(), "existing=%d", tc.existing)
+		for k, want := range src {
+			got, _ := m.Get(k)
+			assert.Equal(t, want, got, "existing=%d: Get(%d)", tc.existing, k)
+		}
+	}
+}
+
 // ---------- benchmarks ----------
 
 func BenchmarkPut(b *testing.B) {
@@ -481,3 +1526,62 @@ func BenchmarkIterate(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkPutBatch(b *testing.B) {
+	batch := make([]Entry[int, int], 10_000)
+	for i := range batch {
+		batch[i] = Entry[int, int]{Key: (i * 7919) % len(batch), Value: i}
+	}
+	for range b.N {
+		b.StopTimer()
+		m := New[int, int]()
+		for i := range 10_000 {
+			m.Put(i+5000, i)
+		}
+		b.StartTimer()
+		m.PutBatch(batch)
+	}
+}
+
+func BenchmarkPutBatchIndividual(b *testing.B) {
+	batch := make([]Entry[int, int], 10_000)
+	for i := range batch {
+		batch[i] = Entry[int, int]{Key: (i * 7919) % len(batch), Value: i}
+	}
+	for range b.N {
+		b.StopTimer()
+		m := New[int, int]()
+		for i := range 10_000 {
+			m.Put(i+5000, i)
+		}
+		b.StartTimer()
+		for _, e := range batch {
+			m.Put(e.Key, e.Value)
+		}
+	}
+}
+
+func BenchmarkKeysAppend(b *testing.B) {
+	m := New[int, int]()
+	for i := range 10_000 {
+		m.Put(i, i)
+	}
+	b.ResetTimer()
+	for range b.N {
+		var keys []int
+		for k := range m.Keys() {
+			keys = append(keys, k)
+		}
+	}
+}
+
+func BenchmarkKeysSlicePreSized(b *testing.B) {
+	m := New[int, int]()
+	for i := range 10_000 {
+		m.Put(i, i)
+	}
+	b.ResetTimer()
+	for range b.N {
+		m.KeysSlicePreSized()
+	}
+}
@@ -0,0 +1,84 @@
+package sortedmap
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// ErrNotMonotonic is returned by [TransformKeys] when the key function does
+// not preserve the order of the keys.
+var ErrNotMonotonic = errors.New("sortedmap: key function is not strictly increasing")
+
+// TransformKeys returns a new map holding every entry of m with its key
+// mapped through f. f must be strictly increasing over m's keys: walking m
+// in order must produce strictly ascending keys under K2's natural order.
+// The new tree is then built directly from the transformed entries in O(n).
+// If f is not strictly increasing, TransformKeys returns nil and an error
+// wrapping [ErrNotMonotonic]. m is not modified.
+func TransformKeys[K1, K2 cmp.Ordered, V any](m *SortedMap[K1, V], f func(K1) K2) (*SortedMap[K2, V], error) {
+	entries := make([]Entry[K2, V], 0, m.Len())
+	var err error
+	m.inOrder(m.root, func(k K1, v V) bool {
+		nk := f(k)
+		// Compare as the output map will; NaN must not slip past >=.
+		if n := len(entries); n > 0 && cmp.Compare(entries[n-1].Key, nk) >= 0 {
+			err = fmt.Errorf("%w: key %v maps to %v, not above %v", ErrNotMonotonic, k, nk, entries[n-1].Key)
+			return false
+		}
+		entries = append(entries, Entry[K2, V]{Key: nk, Value: v})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := New[K2, V]()
+	out.load(entries)
+	return out, nil
+}
+
+// Canonicalize returns a new map holding every entry of m with its key
+// replaced by norm(key), such as lowercasing names or rounding timestamps
+// down to a bucket. When several keys normalize to the same key, their
+// values are combined with merge, called as merge(acc, next) in ascending
+// order of the original keys. The result uses m's comparison function, and
+// m is not modified.
+//
+// When norm preserves order, as bucketing typically does, the result is
+// built directly in O(n). Otherwise Canonicalize falls back to inserting
+// each normalized entry, in O(n log n).
+func (m *SortedMap[K, V]) Canonicalize(norm func(K) K, merge func(a, b V) V) *SortedMap[K, V] {
+	out := &SortedMap[K, V]{cmp: m.cmp}
+	entries := make([]Entry[K, V], 0, m.size)
+	ordered := true
+	m.inOrder(m.root, func(k K, v V) bool {
+		nk := norm(k)
+		if n := len(entries); n > 0 {
+			switch c := m.cmp(entries[n-1].Key, nk); {
+			case c == 0:
+				entries[n-1].Value = merge(entries[n-1].Value, v)
+				return true
+			case c > 0:
+				ordered = false
+			}
+		}
+		entries = append(entries, Entry[K, V]{Key: nk, Value: v})
+		return true
+	})
+	if ordered {
+		out.load(entries)
+		return out
+	}
+	// Adjacent collisions are already merged; entries still follow the
+	// original key order, so inserting them keeps merge's argument order.
+	for _, e := range entries {
+		out.root = out.upsert(out.root, e.Key, func(old V, exists bool) V {
+			if exists {
+				return merge(old, e.Value)
+			}
+			return e.Value
+		})
+		out.root.color = black
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package sortedmap
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformKeys(t *testing.T) {
+	m := New[int, string]()
+	for i := range 100 {
+		m.Put(i, fmt.Sprint(i))
+	}
+	// Seconds to milliseconds.
+	ms, err := TransformKeys(m, func(k int) int64 { return int64(k) * 1000 })
+	require.NoError(t, err)
+	checkLLRB(t, ms)
+	require.Equal(t, 100, ms.Len())
+	v, ok := ms.Get(42_000)
+	assert.True(t, ok && v == "42", "Get(42000) = (%q, %v), want (\"42\", true)", v, ok)
+
+	padded, err := TransformKeys(m, func(k int) string { return fmt.Sprintf("%03d", k) })
+	require.NoError(t, err)
+	assert.Equal(t, "000", slices.Collect(padded.Keys())[0])
+	assert.Equal(t, 100, m.Len(), "original should be unchanged")
+}
+
+func TestTransformKeysNotMonotonic(t *testing.T) {
+	m := New[int, int]()
+	for i := range 10 {
+		m.Put(i, i)
+	}
+	_, err := TransformKeys(m, func(k int) int { return -k })
+	assert.ErrorIs(t, err, ErrNotMonotonic)
+
+	_, err = TransformKeys(m, func(k int) int { return k / 2 }) // not strictly increasing
+	assert.ErrorIs(t, err, ErrNotMonotonic)
+
+	_, err = TransformKeys(m, func(k int) string { return fmt.Sprint(k) }) // single digits sort the same as text
+	assert.NoError(t, err)
+}
+
+func TestTransformKeysNaN(t *testing.T) {
+	m := New[int, int]()
+	for i := 1; i <= 3; i++ {
+		m.Put(i, i)
+	}
+	_, err := TransformKeys(m, func(k int) float64 {
+		if k == 2 {
+			return math.NaN()
+		}
+		return float64(k)
+	})
+	assert.ErrorIs(t, err, ErrNotMonotonic, "NaN sorts before every number in the output map")
+
+	_, err = TransformKeys(m, func(k int) float64 {
+		if k == 1 {
+			return math.NaN()
+		}
+		return float64(k)
+	})
+	assert.NoError(t, err, "a leading NaN is consistent with the output ordering")
+}
+
+func TestTransformKeysEmpty(t *testing.T) {
+	out, err := TransformKeys(New[int, int](), func(k int) float64 { return float64(k) })
+	require.NoError(t, err)
+	assert.True(t, out.IsEmpty())
+	out.Put(1.5, 1)
+	assert.Equal(t, 1, out.Len())
+}
+
+func TestCanonicalize(t *testing.T) {
+	// Bucketing timestamps to tens preserves order.
+	m := New[int, int]()
+	for _, k := range []int{1, 5, 12, 19, 20, 47} {
+		m.Put(k, 1)
+	}
+	sum := func(a, b int) int { return a + b }
+	buckets := m.Canonicalize(func(k int) int { return k / 10 * 10 }, sum)
+	checkLLRB(t, buckets)
+	assert.Equal(t, []Entry[int, int]{{0, 2}, {10, 2}, {20, 1}, {40, 1}}, slices.Collect(buckets.Entries()))
+	assert.Equal(t, 6, m.Len(), "original should be unaffected")
+}
+
+func TestCanonicalizeOutOfOrder(t *testing.T) {
+	m := New[string, string]()
+	for _, k := range []string{"B", "a", "b", "C", "A"} {
+		m.Put(k, k)
+	}
+	// Lowercasing reorders keys: "A" < "B" < "C" < "a" < "b".
+	concat := func(a, b string) string { return a + "+" + b }
+	lower := m.Canonicalize(strings.ToLower, concat)
+	checkLLRB(t, lower)
+	assert.Equal(t, []Entry[string, string]{
+		{"a", "A+a"}, {"b", "B+b"}, {"c", "C"},
+	}, slices.Collect(lower.Entries()), "expected values merged in original key order")
+	assert.Equal(t, 3, lower.Len())
+
+	empty := New[int, int]().Canonicalize(func(k int) int { return k }, nil)
+	assert.True(t, empty.IsEmpty())
+}